@@ -0,0 +1,111 @@
+// Package audit records every resource test attempt for post-mortems of slow startups
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+// SchemaVersion is incremented on incompatible changes of Record
+const SchemaVersion = 1
+
+type (
+	// Record is a JSON line written for every attempt, fields are only added within a schema version
+	Record struct {
+		Schema int `json:"schema"`
+		// Time is the start of the attempt
+		Time     time.Time `json:"time"`
+		Hostname string    `json:"hostname"`
+		PID      int       `json:"pid"`
+		Resource string    `json:"resource"`
+		Scheme   string    `json:"scheme"`
+		Attempt  int       `json:"attempt"`
+		Latency  float64   `json:"latency_ms"`
+		Outcome  string    `json:"outcome"`
+		Error    string    `json:"error,omitempty"`
+	}
+
+	// Log writes records of attempts of any number of runs
+	Log struct {
+		mu       sync.Mutex
+		enc      *json.Encoder
+		closer   io.Closer
+		hostname string
+		pid      int
+	}
+)
+
+// New creates a log writing to a given writer
+func New(w io.Writer) *Log {
+	hostname, _ := os.Hostname()
+
+	return &Log{
+		enc:      json.NewEncoder(w),
+		hostname: hostname,
+		pid:      os.Getpid(),
+	}
+}
+
+// Open creates a log appending to a given file, it is created if it does not exist
+func Open(path string) (*Log, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+
+	if err != nil {
+		return nil, err
+	}
+
+	l := New(f)
+	l.closer = f
+
+	return l, nil
+}
+
+// Close closes the file opened by Open
+func (l *Log) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+
+	return l.closer.Close()
+}
+
+// Option returns a runner option recording every attempt, write errors are ignored
+func (l *Log) Option() waitfor.Option {
+	return waitfor.WithAttemptHook(l.record)
+}
+
+func (l *Log) record(_ context.Context, attempt waitfor.Attempt) {
+	r := Record{
+		Schema:   SchemaVersion,
+		Time:     time.Now().Add(-attempt.Duration).UTC(),
+		Hostname: l.hostname,
+		PID:      l.pid,
+		Resource: attempt.Resource,
+		Attempt:  attempt.Number,
+		Latency:  float64(attempt.Duration.Microseconds()) / 1000,
+		Outcome:  waitfor.OutcomeReady,
+	}
+
+	if u, err := url.Parse(attempt.Resource); err == nil {
+		r.Scheme = u.Scheme
+	}
+
+	r.Resource = waitfor.Redact(attempt.Resource)
+
+	if attempt.Err != nil {
+		r.Outcome = waitfor.OutcomeFailed
+		r.Error = attempt.Err.Error()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_ = l.enc.Encode(r)
+}
@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyResource fails a given number of tests before it becomes available
+type flakyResource struct {
+	failures int
+}
+
+func (f *flakyResource) Test(_ context.Context) error {
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("connection refused")
+	}
+
+	return nil
+}
+
+func newRunner() *waitfor.Runner {
+	return waitfor.New(waitfor.ResourceConfig{
+		Scheme: []string{"flaky"},
+		Factory: func(_ *url.URL) (waitfor.Resource, error) {
+			return &flakyResource{failures: 1}, nil
+		},
+	})
+}
+
+func TestLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	for i := 0; i < 2; i++ {
+		l, err := Open(path)
+
+		assert.NoError(t, err)
+		assert.NoError(t, newRunner().Test(context.Background(), []string{"flaky://user:secret@db:5432"}, waitfor.WithInterval(0), l.Option()))
+		assert.NoError(t, l.Close())
+	}
+
+	f, err := os.Open(path)
+
+	assert.NoError(t, err)
+
+	defer f.Close()
+
+	var records []Record
+
+	s := bufio.NewScanner(f)
+
+	for s.Scan() {
+		var r Record
+
+		assert.NoError(t, json.Unmarshal(s.Bytes(), &r))
+		records = append(records, r)
+	}
+
+	assert.Len(t, records, 4)
+
+	r := records[0]
+
+	assert.Equal(t, SchemaVersion, r.Schema)
+	assert.Equal(t, os.Getpid(), r.PID)
+	assert.Equal(t, "flaky://user:xxxxx@db:5432", r.Resource)
+	assert.Equal(t, "flaky", r.Scheme)
+	assert.Equal(t, 1, r.Attempt)
+	assert.Equal(t, waitfor.OutcomeFailed, r.Outcome)
+	assert.Equal(t, "connection refused", r.Error)
+	assert.False(t, r.Time.IsZero())
+
+	assert.Equal(t, 2, records[1].Attempt)
+	assert.Equal(t, waitfor.OutcomeReady, records[1].Outcome)
+	assert.Empty(t, records[1].Error)
+}
@@ -0,0 +1,546 @@
+// Command waitfor tests resource availability and then replaces itself with a given program.
+//
+// Usage:
+//
+//	waitfor [flags] resource... [-- program args...]
+//
+// Long resource lists are read from files with one resource per line by the repeatable -f flag, "-" reads the standard input.
+//
+// Resources, retry settings and the program may also be described in a config file, see waitfor.LoadConfig.
+// WAITFOR_ATTEMPTS, WAITFOR_INTERVAL, WAITFOR_MAX_INTERVAL and WAITFOR_TIMEOUT environment variables
+// override retry settings of the file and whitespace separated WAITFOR_RESOURCES are added to its resources,
+// the program is only set by the file or arguments. Flags and arguments take precedence over both.
+//
+// -summary prints a table of tested resources with their status, attempts and duration to the standard error,
+// statuses are colored on terminals unless -no-color or NO_COLOR is set.
+//
+// In a Kubernetes init container -init writes failed resources to /dev/termination-log
+// and exits with 143 when the pod is stopped, -marker creates a file once resources are available:
+//
+//	args: ["-init", "-config", "/etc/waitfor/waitfor.yaml", "-marker", "/shared/ready"]
+//
+// With -serve or -serve-grpc the command keeps resources under watch and serves their state
+// over a REST or gRPC API instead, see the server package. SIGHUP and changes of the config file
+// and resource lists reload the configuration, resources are added and removed without a restart.
+// The APIs are not authenticated: addresses without a host listen on localhost and resources
+// are only added over the APIs with -allow-add, limited to server.NetworkSchemes.
+//
+// Exit status, see waitfor.ExitCode:
+//
+//	0    resources are available
+//	1    resources did not become available
+//	2    invalid flags, arguments or configuration
+//	3    any other failure, e.g. the server cannot listen
+//	126  the program cannot be executed
+//	127  the program is not found
+//	128+n  terminated by signal n, e.g. 143 for SIGTERM in the init container mode
+//
+// Once resources are available the command is replaced by the program, so the status of the program is its own.
+//
+// For example, in a Dockerfile:
+//
+//	ENTRYPOINT ["waitfor", "-attempts", "10", "postgres://db:5432", "http://api:8080/health", "--", "myapp"]
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/initcontainer"
+	"github.com/go-waitfor/waitfor/progress"
+	"github.com/go-waitfor/waitfor/report"
+	"github.com/go-waitfor/waitfor/server"
+	"google.golang.org/grpc"
+)
+
+// envConfig is a variable with a config file path
+const envConfig = "WAITFOR_CONFIG"
+
+// exit codes of the command follow waitfor.ExitCode, a program which replaces it exits with its own codes
+const (
+	exitOK          = waitfor.ExitOK
+	exitUnavailable = waitfor.ExitUnavailable
+	exitUsage       = waitfor.ExitUsage
+	// exitTerminated is reported in the init container mode when the pod is stopped during the test,
+	// so it is not mistaken for unavailable resources
+	exitTerminated = waitfor.ExitSignal + int(syscall.SIGTERM)
+)
+
+type (
+	// resourceFlags collects repeated resource flags
+	resourceFlags []string
+
+	config struct {
+		resources   []string
+		attempts    uint64
+		interval    uint64
+		maxInterval uint64
+		timeout     uint64
+		verbose     bool
+		summary     bool
+		noColor     bool
+		init        bool
+		marker      string
+		termLog     string
+		serve       string
+		serveGRPC   string
+		allowAdd    bool
+		program     waitfor.Program
+		// setters are options of the config file and environment variables
+		setters []waitfor.Option
+		// files are the config file and resource lists watched in the server mode
+		files []string
+	}
+)
+
+func (f *resourceFlags) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *resourceFlags) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stdin, os.Stderr)
+
+	stop()
+	os.Exit(code)
+}
+
+// run tests resources and executes a program, it returns an exit code
+func run(ctx context.Context, args []string, stdin io.Reader, stderr io.Writer) int {
+	in := &stdinCache{r: stdin}
+	cfg, err := parseFlags(args, in, stderr)
+
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	if cfg.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.timeout)*time.Second)
+		defer cancel()
+	}
+
+	runner := waitfor.New(builtins()...)
+
+	if cfg.serve != "" || cfg.serveGRPC != "" {
+		// the configuration is parsed again on reloads, the standard input is replayed
+		load := func() (*config, error) {
+			return parseFlags(args, in, io.Discard)
+		}
+
+		if err := serve(ctx, cfg, runner, load, stderr); err != nil {
+			fmt.Fprintln(stderr, err)
+			return waitfor.ExitCode(err)
+		}
+
+		return exitOK
+	}
+
+	setters := cfg.options(stderr)
+
+	if cfg.program.Executable == "" {
+		err = runner.Test(ctx, cfg.resources, setters...)
+	} else {
+		err = runner.Exec(ctx, cfg.program, setters...)
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		if cfg.init && errors.Is(ctx.Err(), context.Canceled) {
+			return exitTerminated
+		}
+
+		return waitfor.ExitCode(err)
+	}
+
+	return exitOK
+}
+
+func parseFlags(args []string, stdin *stdinCache, stderr io.Writer) (*config, error) {
+	var resources, lists resourceFlags
+	var file string
+
+	cfg := new(config)
+	fs := flag.NewFlagSet("waitfor", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: waitfor [flags] resource... [-- program args...]")
+		fs.PrintDefaults()
+	}
+
+	fs.Var(&resources, "r", "resource `url` to test, may be repeated")
+	fs.Var(&lists, "f", "`path` to a file of resource urls, one per line, \"-\" reads the standard input, may be repeated")
+	fs.Uint64Var(&cfg.attempts, "attempts", 5, "number of retries of every resource, 0 retries until the timeout")
+	fs.Uint64Var(&cfg.interval, "interval", 5, "initial interval between attempts in `seconds`")
+	fs.Uint64Var(&cfg.maxInterval, "max-interval", 60, "maximum interval between attempts in `seconds`")
+	fs.Uint64Var(&cfg.timeout, "timeout", 0, "overall timeout in `seconds`, 0 means no timeout")
+	fs.BoolVar(&cfg.verbose, "v", false, "log every attempt")
+	fs.BoolVar(&cfg.summary, "summary", false, "print a table of resources with their status, attempts and duration once they are tested")
+	fs.BoolVar(&cfg.noColor, "no-color", false, "do not color statuses of the summary, they are colored on terminals unless NO_COLOR is set")
+	fs.StringVar(&file, "config", os.Getenv(envConfig), "`path` to a YAML or JSON config file, e.g. a mounted ConfigMap, defaults to "+envConfig)
+	fs.StringVar(&cfg.serve, "serve", "", "watch resources and serve the REST API on a given `address` instead of waiting for them, an address without a host listens on localhost")
+	fs.StringVar(&cfg.serveGRPC, "serve-grpc", "", "watch resources and serve the gRPC API on a given `address` instead of waiting for them")
+	fs.BoolVar(&cfg.allowAdd, "allow-add", false, "allow adding and removing network resources over the unauthenticated APIs of -serve and -serve-grpc")
+	fs.BoolVar(&cfg.init, "init", false, "Kubernetes init container mode, failed resources are written to the termination log")
+	fs.StringVar(&cfg.marker, "marker", "", "`path` of a file created when resources are available")
+	fs.StringVar(&cfg.termLog, "termination-log", "", "`path` of a file describing failed resources, defaults to "+initcontainer.DefaultTerminationLog+" in the init container mode")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	program, err := waitfor.ParseProgram(fs.Args())
+
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	if cfg.init && !set["termination-log"] {
+		cfg.termLog = initcontainer.DefaultTerminationLog
+	}
+
+	if file != "" {
+		fileCfg, err := waitfor.LoadConfig(file)
+
+		if err != nil {
+			return nil, err
+		}
+
+		program = cfg.apply(fileCfg, set, program)
+		cfg.files = append(cfg.files, file)
+	}
+
+	envCfg, err := waitfor.ConfigFromEnv()
+
+	if err != nil {
+		return nil, err
+	}
+
+	program = cfg.apply(envCfg, set, program)
+
+	for _, path := range lists {
+		list, err := readResources(path, stdin)
+
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.resources = append(cfg.resources, list...)
+
+		if path != "-" {
+			cfg.files = append(cfg.files, path)
+		}
+	}
+
+	cfg.resources = append(cfg.resources, resources...)
+	cfg.resources = append(cfg.resources, program.Resources...)
+	cfg.program = program
+	cfg.program.Resources = cfg.resources
+
+	if len(cfg.resources) == 0 && cfg.program.Executable == "" && cfg.serve == "" && cfg.serveGRPC == "" {
+		return nil, fmt.Errorf("%q: no resources to test: %w", "args", waitfor.ErrInvalidArgument)
+	}
+
+	return cfg, nil
+}
+
+// serve watches resources and serves the REST and gRPC APIs until the context is done,
+// resources are checked every interval and may be added at runtime.
+// The configuration is reloaded by load on SIGHUP and on changes of the config file and resource lists.
+func serve(ctx context.Context, cfg *config, runner *waitfor.Runner, load func() (*config, error), stderr io.Writer) error {
+	srv := server.New(runner, cfg.serverOptions()...)
+
+	if err := srv.Sync(cfg.resources); err != nil {
+		return err
+	}
+
+	reload := func() {
+		next, err := load()
+
+		if err != nil {
+			fmt.Fprintln(stderr, "reload:", err)
+			return
+		}
+
+		srv.Reconfigure(next.serverOptions()...)
+
+		if err := srv.Sync(next.resources); err != nil {
+			fmt.Fprintln(stderr, "reload:", err)
+		}
+	}
+
+	var stops []func()
+
+	defer func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}()
+
+	errs := make(chan error, 2)
+
+	if cfg.serve != "" {
+		l, err := net.Listen("tcp", listenAddress(cfg.serve))
+
+		if err != nil {
+			return err
+		}
+
+		httpSrv := &http.Server{Handler: srv.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+		go func() {
+			errs <- httpSrv.Serve(l)
+		}()
+
+		stops = append(stops, func() {
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+			defer cancel()
+
+			_ = httpSrv.Shutdown(ctx)
+		})
+	}
+
+	if cfg.serveGRPC != "" {
+		l, err := net.Listen("tcp", listenAddress(cfg.serveGRPC))
+
+		if err != nil {
+			return err
+		}
+
+		grpcSrv := grpc.NewServer()
+		srv.RegisterGRPC(grpcSrv)
+
+		go func() {
+			errs <- grpcSrv.Serve(l)
+		}()
+
+		stops = append(stops, grpcSrv.GracefulStop)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		_ = srv.Run(ctx)
+	}()
+
+	go func() {
+		if err := watchConfig(ctx, cfg.files, reload); err != nil {
+			fmt.Fprintln(stderr, "watch:", err)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-errs:
+		return err
+	}
+}
+
+// watchConfig calls reload on SIGHUP and on changes of given files until the context is done
+func watchConfig(ctx context.Context, files []string, reload func()) error {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	defer signal.Stop(hup)
+
+	watcher, err := fsnotify.NewWatcher()
+
+	if err != nil {
+		return err
+	}
+
+	defer watcher.Close()
+
+	names := make(map[string]bool, len(files))
+
+	// directories are watched since editors and ConfigMap updates replace files instead of writing them
+	for _, file := range files {
+		names[filepath.Clean(file)] = true
+
+		if err := watcher.Add(filepath.Dir(file)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-hup:
+			reload()
+		case event := <-watcher.Events:
+			// a mounted ConfigMap is updated by swapping its ..data link
+			if names[filepath.Clean(event.Name)] || filepath.Base(event.Name) == "..data" {
+				reload()
+			}
+		case err := <-watcher.Errors:
+			return err
+		}
+	}
+}
+
+// listenAddress makes an address without a host, e.g. ":8080", listen on localhost only,
+// the APIs are not authenticated and all interfaces are opted in with e.g. "0.0.0.0:8080"
+func listenAddress(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+
+	if err != nil || host != "" {
+		return addr
+	}
+
+	return net.JoinHostPort("localhost", port)
+}
+
+// serverOptions converts the config into server options
+func (c *config) serverOptions() []server.Option {
+	var setters []server.Option
+
+	if c.interval > 0 {
+		setters = append(setters, server.WithInterval(time.Duration(c.interval)*time.Second))
+	}
+
+	if c.allowAdd {
+		setters = append(setters, server.WithRuntimeResources())
+	}
+
+	return setters
+}
+
+// stdinCache reads the standard input once and replays it on configuration reloads
+type stdinCache struct {
+	r    io.Reader
+	once sync.Once
+	data []byte
+	err  error
+}
+
+func (c *stdinCache) open() (io.Reader, error) {
+	c.once.Do(func() {
+		c.data, c.err = io.ReadAll(c.r)
+	})
+
+	return bytes.NewReader(c.data), c.err
+}
+
+// readResources reads a resource list from a file or the standard input
+func readResources(path string, stdin *stdinCache) ([]string, error) {
+	if path == "-" {
+		r, err := stdin.open()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return waitfor.ReadResources(r)
+	}
+
+	return waitfor.LoadResources(path)
+}
+
+// apply uses a config for settings not given by flags and returns the program to run
+func (c *config) apply(src *waitfor.Config, set map[string]bool, program waitfor.Program) waitfor.Program {
+	settings := []struct {
+		flag  string
+		value *uint64
+		dst   *uint64
+	}{
+		{"attempts", src.Attempts, &c.attempts},
+		{"interval", src.Interval, &c.interval},
+		{"max-interval", src.MaxInterval, &c.maxInterval},
+	}
+
+	for _, s := range settings {
+		if s.value != nil && !set[s.flag] {
+			*s.dst = *s.value
+		}
+	}
+
+	if src.Timeout > 0 && !set["timeout"] {
+		c.timeout = src.Timeout
+	}
+
+	c.resources = append(c.resources, src.URLs()...)
+	c.setters = append(c.setters, src.Options()...)
+
+	if program.Executable == "" && src.Program != nil {
+		srcProgram := src.ToProgram()
+		srcProgram.Resources = program.Resources
+
+		return srcProgram
+	}
+
+	return program
+}
+
+// options converts the config into runner options
+func (c *config) options(stderr io.Writer) []waitfor.Option {
+	// flags follow the config file options to override its global settings
+	setters := append(c.setters,
+		waitfor.WithAttempts(c.attempts),
+		waitfor.WithInterval(c.interval),
+		waitfor.WithMaxInterval(c.maxInterval),
+	)
+
+	if c.marker != "" || c.termLog != "" {
+		setters = append(setters, initcontainer.OnCompletion(
+			initcontainer.WithMarker(c.marker),
+			initcontainer.WithTerminationLog(c.termLog),
+			initcontainer.WithErrorHandler(func(err error) {
+				fmt.Fprintln(stderr, err)
+			}),
+		))
+	}
+
+	if c.verbose {
+		setters = append(setters, waitfor.WithLogger(slog.New(slog.NewTextHandler(stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	}
+
+	if c.summary {
+		color := !c.noColor && os.Getenv("NO_COLOR") == ""
+
+		if f, ok := stderr.(*os.File); !ok || !progress.IsTerminal(f) {
+			color = false
+		}
+
+		setters = append(setters, waitfor.WithCompletionHook(func(_ context.Context, results []waitfor.ResourceResult, _ error) {
+			_ = report.WriteTable(stderr, results, color)
+		}))
+	}
+
+	return setters
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/server/serverpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	ready := filepath.Join(dir, "ready")
+
+	assert.NoError(t, os.WriteFile(ready, nil, 0o600))
+
+	config := filepath.Join(dir, "waitfor.yaml")
+	missing := "file://" + filepath.Join(dir, "missing")
+
+	list := filepath.Join(dir, "resources.txt")
+
+	assert.NoError(t, os.WriteFile(list, []byte("file://"+ready+"\n"+missing+" # not created\n"), 0o600))
+	assert.NoError(t, os.WriteFile(config, []byte("attempts: 1\ninterval: 0\nresources:\n  - "+missing+"\n"), 0o600))
+
+	cases := []struct {
+		args []string
+		code int
+		out  string
+	}{
+		{[]string{"file://" + ready}, exitOK, ""},
+		{[]string{"-r", "file://" + ready, "-attempts", "1", "-interval", "0"}, exitOK, ""},
+		{[]string{"-attempts", "1", "-interval", "0", "file://" + filepath.Join(dir, "missing")}, exitUnavailable, "no such file or directory"},
+		{[]string{"-attempts", "1", "-interval", "0", "unknown://localhost", "--", "true"}, exitUnavailable, "resource with a given scheme is not found"},
+		{[]string{"-v", "file://" + ready}, exitOK, "resource is available"},
+		{[]string{"-summary", "file://" + ready}, exitOK, "ready   1         "},
+		{[]string{"-summary", "-attempts", "1", "-interval", "0", "file://" + filepath.Join(dir, "missing")}, exitUnavailable, "0 of 1 resources ready"},
+		{[]string{"file://" + ready, "--", "waitfor-missing-executable"}, waitfor.ExitNotFound, "executable file not found"},
+		{[]string{}, exitUsage, "no resources to test"},
+		{[]string{"file://" + ready, "--"}, exitUsage, "missing executable"},
+		{[]string{"-attempts", "x"}, exitUsage, "invalid value"},
+		{[]string{"-h"}, exitOK, "Usage: waitfor"},
+		{[]string{"-config", config, "file://" + ready}, exitUnavailable, "no such file or directory"},
+		{[]string{"-config", filepath.Join(dir, "none.yaml")}, exitUsage, "no such file or directory"},
+		{[]string{"-f", "-"}, exitOK, ""},
+		{[]string{"-attempts", "1", "-interval", "0", "-f", "-", "-f", list}, exitUnavailable, "missing: no such file or directory"},
+		{[]string{"-f", filepath.Join(dir, "none.txt")}, exitUsage, "no such file or directory"},
+	}
+
+	for _, c := range cases {
+		var stderr bytes.Buffer
+
+		code := run(context.Background(), c.args, strings.NewReader("# stdin\nfile://"+ready), &stderr)
+
+		assert.Equal(t, c.code, code, c.args)
+		assert.Contains(t, stderr.String(), c.out, c.args)
+	}
+}
+
+func TestRun_Env(t *testing.T) {
+	ready := filepath.Join(t.TempDir(), "ready")
+
+	assert.NoError(t, os.WriteFile(ready, nil, 0o600))
+
+	t.Setenv(waitfor.EnvResources, "file://"+ready)
+	t.Setenv(waitfor.EnvAttempts, "1")
+
+	var stderr bytes.Buffer
+
+	assert.Equal(t, exitOK, run(context.Background(), nil, nil, &stderr))
+
+	t.Setenv(waitfor.EnvTimeout, "soon")
+
+	assert.Equal(t, exitUsage, run(context.Background(), nil, nil, &stderr))
+	assert.Contains(t, stderr.String(), waitfor.EnvTimeout)
+}
+
+func TestRun_Init(t *testing.T) {
+	dir := t.TempDir()
+	ready := filepath.Join(dir, "ready")
+	marker := filepath.Join(dir, "marker")
+	log := filepath.Join(dir, "termination-log")
+
+	assert.NoError(t, os.WriteFile(ready, nil, 0o600))
+
+	var stderr bytes.Buffer
+
+	assert.Equal(t, exitOK, run(context.Background(), []string{"-init", "-termination-log", log, "-marker", marker, "file://" + ready}, nil, &stderr))
+	assert.FileExists(t, marker)
+
+	code := run(context.Background(), []string{"-init", "-termination-log", log, "-marker", marker, "-attempts", "1", "-interval", "0", "file://" + filepath.Join(dir, "missing")}, nil, &stderr)
+
+	assert.Equal(t, exitUnavailable, code)
+	assert.NoFileExists(t, marker)
+
+	message, err := os.ReadFile(log)
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(message), "missing: 2 attempts in")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Equal(t, exitTerminated, run(ctx, []string{"-init", "-termination-log", log, "file://" + filepath.Join(dir, "missing")}, nil, &stderr))
+	assert.Equal(t, exitUnavailable, run(ctx, []string{"file://" + filepath.Join(dir, "missing")}, nil, &stderr))
+}
+
+func TestRun_Serve(t *testing.T) {
+	ready := filepath.Join(t.TempDir(), "ready")
+
+	assert.NoError(t, os.WriteFile(ready, nil, 0o600))
+
+	addr, grpcAddr := freeAddr(t), freeAddr(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan int)
+
+	go func() {
+		done <- run(ctx, []string{"-serve", addr, "-serve-grpc", grpcAddr, "file://" + ready}, nil, io.Discard)
+	}()
+
+	assert.Eventually(t, func() bool {
+		res, err := http.Get("http://" + addr + "/ready")
+
+		if err != nil {
+			return false
+		}
+
+		_ = res.Body.Close()
+
+		return res.StatusCode == http.StatusOK
+	}, 5*time.Second, 20*time.Millisecond)
+
+	conn, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+
+	defer conn.Close()
+
+	list, err := serverpb.NewReadinessClient(conn).ListResources(context.Background(), &serverpb.ListResourcesRequest{})
+
+	assert.NoError(t, err)
+	assert.True(t, list.GetReady())
+
+	res, err := http.Post("http://"+addr+"/resources", "application/json", strings.NewReader(`{"resource": "exec:///bin/true"}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+	assert.NoError(t, res.Body.Close())
+
+	cancel()
+
+	assert.Equal(t, exitOK, <-done)
+}
+
+func TestRun_Serve_Reload(t *testing.T) {
+	dir := t.TempDir()
+	list := filepath.Join(dir, "resources.txt")
+
+	assert.NoError(t, os.WriteFile(list, []byte("file:///db\n"), 0o600))
+
+	addr := freeAddr(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan int)
+
+	go func() {
+		done <- run(ctx, []string{"-serve", addr, "-f", list}, nil, io.Discard)
+	}()
+
+	resources := func() []string {
+		res, err := http.Get("http://" + addr + "/resources")
+
+		if err != nil {
+			return nil
+		}
+
+		defer res.Body.Close()
+
+		var statuses []struct {
+			Resource string `json:"resource"`
+		}
+
+		_ = json.NewDecoder(res.Body).Decode(&statuses)
+
+		var locations []string
+
+		for _, status := range statuses {
+			locations = append(locations, status.Resource)
+		}
+
+		return locations
+	}
+
+	assert.Eventually(t, func() bool {
+		return slices.Equal(resources(), []string{"file:///db"})
+	}, 5*time.Second, 20*time.Millisecond)
+
+	assert.NoError(t, os.WriteFile(list+".tmp", []byte("file:///api\nfile:///cache\n"), 0o600))
+	assert.NoError(t, os.Rename(list+".tmp", list))
+
+	assert.Eventually(t, func() bool {
+		return slices.Equal(resources(), []string{"file:///api", "file:///cache"})
+	}, 5*time.Second, 20*time.Millisecond)
+
+	cancel()
+
+	assert.Equal(t, exitOK, <-done)
+}
+
+func TestListenAddress(t *testing.T) {
+	assert.Equal(t, "localhost:8080", listenAddress(":8080"))
+	assert.Equal(t, "0.0.0.0:8080", listenAddress("0.0.0.0:8080"))
+	assert.Equal(t, "[::1]:8080", listenAddress("[::1]:8080"))
+	assert.Equal(t, "8080", listenAddress("8080"))
+}
+
+func freeAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	defer l.Close()
+
+	return l.Addr().String()
+}
+
+func TestBuiltins(t *testing.T) {
+	schemes := make(map[string]bool)
+
+	for _, c := range builtins() {
+		for _, scheme := range c.Scheme {
+			assert.False(t, schemes[scheme], scheme)
+			schemes[scheme] = true
+		}
+	}
+
+	r := waitfor.New(builtins()...)
+
+	for _, scheme := range []string{"tcp", "http+unix", "mysql", "dns+srv", "k8s-job"} {
+		_, err := r.Resources().Resolve(scheme + "://localhost")
+
+		if err != nil {
+			assert.NotContains(t, err.Error(), "not found", scheme)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/resources/amqp"
+	"github.com/go-waitfor/waitfor/resources/azblob"
+	"github.com/go-waitfor/waitfor/resources/cassandra"
+	"github.com/go-waitfor/waitfor/resources/clickhouse"
+	"github.com/go-waitfor/waitfor/resources/consul"
+	"github.com/go-waitfor/waitfor/resources/dir"
+	"github.com/go-waitfor/waitfor/resources/disk"
+	"github.com/go-waitfor/waitfor/resources/dynamodb"
+	"github.com/go-waitfor/waitfor/resources/elasticsearch"
+	"github.com/go-waitfor/waitfor/resources/etcd"
+	"github.com/go-waitfor/waitfor/resources/exec"
+	"github.com/go-waitfor/waitfor/resources/file"
+	"github.com/go-waitfor/waitfor/resources/ftp"
+	"github.com/go-waitfor/waitfor/resources/gcs"
+	"github.com/go-waitfor/waitfor/resources/grpc"
+	"github.com/go-waitfor/waitfor/resources/host"
+	"github.com/go-waitfor/waitfor/resources/http"
+	"github.com/go-waitfor/waitfor/resources/influxdb"
+	"github.com/go-waitfor/waitfor/resources/jwks"
+	"github.com/go-waitfor/waitfor/resources/k8s"
+	"github.com/go-waitfor/waitfor/resources/kafka"
+	"github.com/go-waitfor/waitfor/resources/mail"
+	"github.com/go-waitfor/waitfor/resources/migrations"
+	"github.com/go-waitfor/waitfor/resources/mongodb"
+	"github.com/go-waitfor/waitfor/resources/mysql"
+	"github.com/go-waitfor/waitfor/resources/nats"
+	"github.com/go-waitfor/waitfor/resources/ntp"
+	"github.com/go-waitfor/waitfor/resources/oci"
+	"github.com/go-waitfor/waitfor/resources/oidc"
+	"github.com/go-waitfor/waitfor/resources/pid"
+	"github.com/go-waitfor/waitfor/resources/portfree"
+	"github.com/go-waitfor/waitfor/resources/promql"
+	"github.com/go-waitfor/waitfor/resources/pubsub"
+	"github.com/go-waitfor/waitfor/resources/rabbitmq"
+	"github.com/go-waitfor/waitfor/resources/redis"
+	"github.com/go-waitfor/waitfor/resources/s3"
+	"github.com/go-waitfor/waitfor/resources/serial"
+	"github.com/go-waitfor/waitfor/resources/snmp"
+	"github.com/go-waitfor/waitfor/resources/sql"
+	"github.com/go-waitfor/waitfor/resources/sqs"
+	"github.com/go-waitfor/waitfor/resources/srv"
+	"github.com/go-waitfor/waitfor/resources/ssh"
+	"github.com/go-waitfor/waitfor/resources/tcp"
+	"github.com/go-waitfor/waitfor/resources/time"
+	"github.com/go-waitfor/waitfor/resources/tls"
+	"github.com/go-waitfor/waitfor/resources/udp"
+	"github.com/go-waitfor/waitfor/resources/unix"
+	"github.com/go-waitfor/waitfor/resources/vault"
+	"github.com/go-waitfor/waitfor/resources/websocket"
+	"github.com/go-waitfor/waitfor/resources/zookeeper"
+)
+
+// builtins returns configs of all resources shipped with the module
+func builtins() []waitfor.ResourceConfig {
+	return []waitfor.ResourceConfig{
+		amqp.Use(),
+		azblob.Use(),
+		cassandra.Use(),
+		clickhouse.Use(),
+		consul.Use(),
+		dir.Use(),
+		disk.Use(),
+		dynamodb.Use(),
+		elasticsearch.Use(),
+		etcd.Use(),
+		exec.Use(),
+		file.Use(),
+		ftp.Use(),
+		gcs.Use(),
+		grpc.Use(),
+		host.Use(),
+		http.Use(),
+		influxdb.Use(),
+		jwks.Use(),
+		k8s.Use(),
+		kafka.Use(),
+		mail.Use(),
+		migrations.Use(),
+		mongodb.Use(),
+		mysql.Use(),
+		nats.Use(),
+		ntp.Use(),
+		oci.Use(),
+		oidc.Use(),
+		pid.Use(),
+		portfree.Use(),
+		promql.Use(),
+		pubsub.Use(),
+		rabbitmq.Use(),
+		redis.Use(),
+		s3.Use(),
+		serial.Use(),
+		snmp.Use(),
+		sql.Use(),
+		sqs.Use(),
+		srv.Use(),
+		ssh.Use(),
+		tcp.Use(),
+		time.Use(),
+		tls.Use(),
+		udp.Use(),
+		unix.Use(),
+		vault.Use(),
+		websocket.Use(),
+		zookeeper.Use(),
+	}
+}
@@ -0,0 +1,269 @@
+package waitfor
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables read by ConfigFromEnv, intervals and the timeout are in seconds
+const (
+	EnvResources   = "WAITFOR_RESOURCES"
+	EnvAttempts    = "WAITFOR_ATTEMPTS"
+	EnvInterval    = "WAITFOR_INTERVAL"
+	EnvMaxInterval = "WAITFOR_MAX_INTERVAL"
+	EnvTimeout     = "WAITFOR_TIMEOUT"
+)
+
+type (
+	// Config is a declarative description of resources, retry settings and a program,
+	// e.g. loaded from waitfor.yaml or waitfor.json. Unset retry settings keep their defaults.
+	Config struct {
+		Attempts    *uint64 `yaml:"attempts"`
+		Interval    *uint64 `yaml:"interval"`
+		MaxInterval *uint64 `yaml:"maxInterval"`
+		// Timeout is the overall timeout in seconds, it is applied by the caller
+		Timeout   uint64         `yaml:"timeout"`
+		Resources []ResourceSpec `yaml:"resources"`
+		Program   *ProgramSpec   `yaml:"program"`
+	}
+
+	// ResourceSpec is a resource location with optional retry settings overriding the global ones,
+	// a plain string is a location without overrides
+	ResourceSpec struct {
+		URL         string  `yaml:"url"`
+		Attempts    *uint64 `yaml:"attempts"`
+		Interval    *uint64 `yaml:"interval"`
+		MaxInterval *uint64 `yaml:"maxInterval"`
+	}
+
+	// ProgramSpec describes a program started once resources are available
+	ProgramSpec struct {
+		Executable    string   `yaml:"executable"`
+		Args          []string `yaml:"args"`
+		Env           []string `yaml:"env"`
+		PostResources []string `yaml:"postResources"`
+	}
+)
+
+// LoadConfig reads a YAML or JSON config file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := ParseConfig(bytes.NewReader(data))
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// ParseConfig decodes a YAML or JSON config, unknown fields are rejected
+func ParseConfig(r io.Reader) (*Config, error) {
+	cfg := new(Config)
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%q: %s: %w", "config", err, ErrInvalidArgument)
+	}
+
+	for i, spec := range cfg.Resources {
+		if spec.URL == "" {
+			return nil, fmt.Errorf("%q: resource %d has no url: %w", "config", i+1, ErrInvalidArgument)
+		}
+	}
+
+	if cfg.Program != nil && cfg.Program.Executable == "" {
+		return nil, fmt.Errorf("%q: program has no executable: %w", "config", ErrInvalidArgument)
+	}
+
+	return cfg, nil
+}
+
+// ConfigFromEnv reads resources and retry settings from WAITFOR_* environment variables.
+// Resources are separated by whitespace or newlines, commas are kept since locations contain them,
+// e.g. mongodb://db1,db2 or http://api?status=2xx,301. Unset variables are left empty.
+func ConfigFromEnv() (*Config, error) {
+	cfg := new(Config)
+
+	for _, resource := range strings.Fields(os.Getenv(EnvResources)) {
+		cfg.Resources = append(cfg.Resources, ResourceSpec{URL: resource})
+	}
+
+	settings := []struct {
+		name  string
+		value **uint64
+	}{
+		{EnvAttempts, &cfg.Attempts},
+		{EnvInterval, &cfg.Interval},
+		{EnvMaxInterval, &cfg.MaxInterval},
+	}
+
+	for _, s := range settings {
+		value, err := envUint(s.name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		*s.value = value
+	}
+
+	timeout, err := envUint(EnvTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout != nil {
+		cfg.Timeout = *timeout
+	}
+
+	return cfg, nil
+}
+
+// ReadResources reads resource locations, one per line.
+// Blank lines and comments starting with "#" at the beginning of a line or after whitespace are skipped.
+func ReadResources(r io.Reader) ([]string, error) {
+	var resources []string
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		if line := strings.TrimSpace(stripComment(scanner.Text())); line != "" {
+			resources = append(resources, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// stripComment removes a comment from a line keeping URL fragments
+func stripComment(line string) string {
+	for i, c := range line {
+		if c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+			return line[:i]
+		}
+	}
+
+	return line
+}
+
+// LoadResources reads resource locations from a file, "-" reads the standard input
+func LoadResources(path string) ([]string, error) {
+	if path == "-" {
+		return ReadResources(os.Stdin)
+	}
+
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return ReadResources(f)
+}
+
+// envUint parses a numeric environment variable, it returns nil if the variable is not set
+func envUint(name string) (*uint64, error) {
+	value, ok := os.LookupEnv(name)
+
+	if !ok || value == "" {
+		return nil, nil
+	}
+
+	n, err := strconv.ParseUint(value, 10, 64)
+
+	if err != nil {
+		return nil, fmt.Errorf("%q: %s: %w", name, err, ErrInvalidArgument)
+	}
+
+	return &n, nil
+}
+
+// UnmarshalYAML accepts a plain location or a mapping with overrides
+func (s *ResourceSpec) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&s.URL)
+	}
+
+	type spec ResourceSpec
+
+	return node.Decode((*spec)(s))
+}
+
+// URLs returns locations of all resources
+func (c *Config) URLs() []string {
+	urls := make([]string, 0, len(c.Resources))
+
+	for _, spec := range c.Resources {
+		urls = append(urls, spec.URL)
+	}
+
+	return urls
+}
+
+// Options returns runner options of the global and per-resource retry settings
+func (c *Config) Options() []Option {
+	setters := retryOptions(c.Attempts, c.Interval, c.MaxInterval)
+
+	for _, spec := range c.Resources {
+		if overrides := retryOptions(spec.Attempts, spec.Interval, spec.MaxInterval); len(overrides) > 0 {
+			setters = append(setters, WithResourceOptions(spec.URL, overrides...))
+		}
+	}
+
+	return setters
+}
+
+// ToProgram returns the configured program testing all resources, it is empty if no program is configured
+func (c *Config) ToProgram() Program {
+	if c.Program == nil {
+		return Program{}
+	}
+
+	return Program{
+		Executable:    c.Program.Executable,
+		Args:          c.Program.Args,
+		Env:           c.Program.Env,
+		Resources:     c.URLs(),
+		PostResources: c.Program.PostResources,
+	}
+}
+
+func retryOptions(attempts, interval, maxInterval *uint64) []Option {
+	var setters []Option
+
+	if attempts != nil {
+		setters = append(setters, WithAttempts(*attempts))
+	}
+
+	if interval != nil {
+		setters = append(setters, WithInterval(*interval))
+	}
+
+	if maxInterval != nil {
+		setters = append(setters, WithMaxInterval(*maxInterval))
+	}
+
+	return setters
+}
@@ -0,0 +1,153 @@
+package waitfor
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig(strings.NewReader(`
+attempts: 10
+interval: 1
+timeout: 120
+resources:
+  - mysql://db:3306
+  - url: http://api:8080/health
+    attempts: 3
+    maxInterval: 5
+program:
+  executable: myapp
+  args: [--port, "8080"]
+  env: [MODE=prod]
+`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), *cfg.Attempts)
+	assert.Equal(t, uint64(1), *cfg.Interval)
+	assert.Nil(t, cfg.MaxInterval)
+	assert.Equal(t, uint64(120), cfg.Timeout)
+	assert.Equal(t, []string{"mysql://db:3306", "http://api:8080/health"}, cfg.URLs())
+	assert.Equal(t, uint64(3), *cfg.Resources[1].Attempts)
+	assert.Len(t, cfg.Options(), 3)
+	assert.Equal(t, Program{
+		Executable: "myapp",
+		Args:       []string{"--port", "8080"},
+		Env:        []string{"MODE=prod"},
+		Resources:  []string{"mysql://db:3306", "http://api:8080/health"},
+	}, cfg.ToProgram())
+
+	opts := newOptions(cfg.Options())
+
+	assert.Equal(t, uint64(10), opts.attempts)
+	assert.Equal(t, uint64(3), opts.forResource("http://api:8080/health").attempts)
+	assert.Equal(t, uint64(10), opts.forResource("mysql://db:3306").attempts)
+}
+
+func TestLoadConfig_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "waitfor.json")
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"resources": ["tcp://db:5432", {"url": "file://./ready", "interval": 2}]}`), 0o600))
+
+	cfg, err := LoadConfig(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tcp://db:5432", "file://./ready"}, cfg.URLs())
+	assert.Nil(t, cfg.Attempts)
+	assert.Empty(t, cfg.ToProgram().Executable)
+}
+
+func TestParseConfig_InvalidArgument(t *testing.T) {
+	for _, data := range []string{
+		"attempt: 5",
+		"attempts: many",
+		"resources: [{attempts: 1}]",
+		"program: {args: [x]}",
+	} {
+		_, err := ParseConfig(strings.NewReader(data))
+
+		assert.ErrorIs(t, err, ErrInvalidArgument, data)
+	}
+}
+
+func TestRunner_Test_ResourceOptions(t *testing.T) {
+	flaky := map[string]*flakyResource{
+		"a": {failures: 3},
+		"b": {failures: 3},
+	}
+
+	r := New(ResourceConfig{
+		Scheme: []string{"flaky"},
+		Factory: func(u *url.URL) (Resource, error) {
+			return flaky[u.Host], nil
+		},
+	})
+
+	err := r.Test(context.Background(), []string{"flaky://a", "flaky://b"}, WithInterval(0), WithAttempts(5), WithResourceOptions("flaky://b", WithAttempts(1)))
+
+	var waitErr *WaitError
+
+	assert.ErrorAs(t, err, &waitErr)
+	assert.Len(t, waitErr.Failed(), 1)
+	assert.Equal(t, "flaky://b", waitErr.Failed()[0].Resource)
+	assert.Equal(t, 4, flaky["a"].calls)
+	assert.Equal(t, 2, flaky["b"].calls)
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv(EnvResources, "mysql://db:3306 http://api:8080/health?status=2xx,301\ttcp://cache:6379\nmongodb://db1,db2/app")
+	t.Setenv(EnvAttempts, "10")
+	t.Setenv(EnvInterval, "")
+	t.Setenv(EnvTimeout, "120")
+
+	cfg, err := ConfigFromEnv()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mysql://db:3306", "http://api:8080/health?status=2xx,301", "tcp://cache:6379", "mongodb://db1,db2/app"}, cfg.URLs())
+	assert.Equal(t, uint64(10), *cfg.Attempts)
+	assert.Nil(t, cfg.Interval)
+	assert.Nil(t, cfg.MaxInterval)
+	assert.Equal(t, uint64(120), cfg.Timeout)
+	assert.Len(t, cfg.Options(), 1)
+}
+
+func TestConfigFromEnv_InvalidArgument(t *testing.T) {
+	t.Setenv(EnvMaxInterval, "-1")
+
+	_, err := ConfigFromEnv()
+
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	assert.ErrorContains(t, err, EnvMaxInterval)
+}
+
+func TestReadResources(t *testing.T) {
+	resources, err := ReadResources(strings.NewReader(`# databases
+mysql://db:3306
+
+  http://api:8080/health#ready # api
+	tcp://cache:6379	#cache
+`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mysql://db:3306", "http://api:8080/health#ready", "tcp://cache:6379"}, resources)
+}
+
+func TestLoadResources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resources.txt")
+
+	assert.NoError(t, os.WriteFile(path, []byte("tcp://db:5432\n"), 0o600))
+
+	resources, err := LoadResources(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tcp://db:5432"}, resources)
+
+	_, err = LoadResources(filepath.Join(t.TempDir(), "missing.txt"))
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,9 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+
+package waitfor
+
+import "os/exec"
+
+func setCredential(_ *exec.Cmd, _ *Credential) error {
+	return ErrNotSupported
+}
@@ -0,0 +1,42 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package waitfor
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setCredential makes a command run as a given user and groups
+func setCredential(cmd *exec.Cmd, c *Credential) error {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+
+	cmd.SysProcAttr.Credential = &syscall.Credential{
+		Uid:    c.UID,
+		Gid:    c.GID,
+		Groups: c.Groups,
+	}
+
+	return nil
+}
+
+// switchCredential changes the user and groups of the current process
+func switchCredential(c *Credential) error {
+	groups := make([]int, 0, len(c.Groups))
+
+	for _, g := range c.Groups {
+		groups = append(groups, int(g))
+	}
+
+	if err := syscall.Setgroups(groups); err != nil {
+		return err
+	}
+
+	if err := syscall.Setgid(int(c.GID)); err != nil {
+		return err
+	}
+
+	return syscall.Setuid(int(c.UID))
+}
@@ -1,8 +1,93 @@
 package waitfor
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
 
 var (
 	ErrWait            = errors.New("failed to wait for resource availability")
 	ErrInvalidArgument = errors.New("invalid argument")
+	ErrNotSupported    = errors.New("operation is not supported on this platform")
+	// ErrPermanent is wrapped by resources which will never become available, e.g. a failed job.
+	// Such resources are not retried.
+	ErrPermanent = errors.New("resource will never become available")
 )
+
+// ExitError is returned when a program exits with a non-zero status
+type ExitError struct {
+	Code   int
+	Stderr []byte
+	err    error
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("program exited with code %d", e.Code)
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.err
+}
+
+// ResourceFailure is a resource which did not become available
+type ResourceFailure struct {
+	Resource string
+	// Attempts is the number of tests made, it is zero if the resource cannot be resolved
+	Attempts int
+	Duration time.Duration
+	// Err is the error of the last attempt
+	Err error
+}
+
+func (f ResourceFailure) Error() string {
+	if f.Attempts == 0 {
+		return fmt.Sprintf("%s: %s", Redact(f.Resource), f.Err)
+	}
+
+	return fmt.Sprintf("%s: %d attempts in %s: %s", Redact(f.Resource), f.Attempts, f.Duration.Round(time.Millisecond), f.Err)
+}
+
+func (f ResourceFailure) Unwrap() error {
+	return f.Err
+}
+
+// WaitError is returned when some of the resources are not available.
+// It matches ErrWait and the errors of every failed resource with errors.Is and errors.As.
+type WaitError struct {
+	failures []ResourceFailure
+	err      error
+}
+
+func newWaitError(failures []ResourceFailure) *WaitError {
+	errs := make([]error, 0, len(failures))
+
+	for _, f := range failures {
+		errs = append(errs, f)
+	}
+
+	return &WaitError{
+		failures: failures,
+		err:      errors.Join(errs...),
+	}
+}
+
+func (e *WaitError) Error() string {
+	var b strings.Builder
+
+	for _, f := range e.failures {
+		b.WriteString(f.Error() + ";")
+	}
+
+	return fmt.Sprintf("%s: %s", ErrWait, b.String())
+}
+
+func (e *WaitError) Unwrap() []error {
+	return []error{ErrWait, e.err}
+}
+
+// Failed returns resources which did not become available in the order of completion
+func (e *WaitError) Failed() []ResourceFailure {
+	return append([]ResourceFailure(nil), e.failures...)
+}
@@ -0,0 +1,37 @@
+package waitfor
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitError(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing")
+	r := New(useFileResource(), useFlakyResource(&flakyResource{}))
+
+	err := r.Test(context.Background(), []string{"file://" + missing, "flaky://localhost"}, WithInterval(0), WithAttempts(1))
+
+	var waitErr *WaitError
+
+	assert.ErrorAs(t, err, &waitErr)
+	assert.ErrorIs(t, err, ErrWait)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	var pathErr *os.PathError
+
+	assert.ErrorAs(t, err, &pathErr)
+	assert.Equal(t, missing, pathErr.Path)
+
+	failed := waitErr.Failed()
+
+	assert.Len(t, failed, 1)
+	assert.Equal(t, "file://"+missing, failed[0].Resource)
+	assert.Equal(t, 2, failed[0].Attempts)
+	assert.True(t, errors.Is(failed[0], fs.ErrNotExist))
+}
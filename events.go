@@ -0,0 +1,87 @@
+package waitfor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	// EventAttempt is written after every resource test attempt
+	EventAttempt = "attempt"
+	// EventResult is written once a resource is available or it is not retried anymore
+	EventResult = "result"
+
+	OutcomeReady  = "ready"
+	OutcomeFailed = "failed"
+)
+
+type (
+	// Event is a JSON object written by the event writer
+	Event struct {
+		Time     time.Time `json:"time"`
+		Type     string    `json:"type"`
+		Resource string    `json:"resource"`
+		// Attempt is the number of an attempt or the number of all attempts of a result
+		Attempt int    `json:"attempt,omitempty"`
+		Outcome string `json:"outcome"`
+		// Duration is the duration of an attempt or the whole wait in milliseconds
+		Duration int64 `json:"duration_ms"`
+		// Delay is the time until the next attempt in milliseconds
+		Delay int64  `json:"delay_ms,omitempty"`
+		Error string `json:"error,omitempty"`
+	}
+
+	// eventWriter serializes events of concurrently tested resources
+	eventWriter struct {
+		mu  sync.Mutex
+		enc *json.Encoder
+	}
+)
+
+// Write resource test events as JSON lines, passwords in resource locations are redacted
+func WithEventWriter(w io.Writer) Option {
+	ew := &eventWriter{enc: json.NewEncoder(w)}
+
+	return func(opts *Options) {
+		WithAttemptHook(ew.attempt)(opts)
+		WithResultHook(ew.result)(opts)
+	}
+}
+
+func (w *eventWriter) attempt(_ context.Context, attempt Attempt) {
+	w.write(Event{
+		Type:     EventAttempt,
+		Resource: attempt.Resource,
+		Attempt:  attempt.Number,
+		Duration: attempt.Duration.Milliseconds(),
+		Delay:    attempt.Delay.Milliseconds(),
+	}, attempt.Err)
+}
+
+func (w *eventWriter) result(_ context.Context, result ResourceResult) {
+	w.write(Event{
+		Type:     EventResult,
+		Resource: result.Resource,
+		Attempt:  result.Attempts,
+		Duration: result.Duration.Milliseconds(),
+	}, result.Err)
+}
+
+func (w *eventWriter) write(e Event, err error) {
+	e.Time = time.Now().UTC()
+	e.Resource = Redact(e.Resource)
+	e.Outcome = OutcomeReady
+
+	if err != nil {
+		e.Outcome = OutcomeFailed
+		e.Error = err.Error()
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_ = w.enc.Encode(e)
+}
@@ -0,0 +1,19 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+
+package waitfor
+
+import "os"
+
+func execProgram(_ Program) error {
+	return ErrNotSupported
+}
+
+// terminate kills a process since termination signals are not supported
+func terminate(p *os.Process) error {
+	return p.Kill()
+}
+
+// signalCode is not supported, the program exit code is used as is
+func signalCode(_ error) (int, bool) {
+	return 0, false
+}
@@ -0,0 +1,51 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package waitfor
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// execProgram replaces the current process with a given program
+func execProgram(program Program) error {
+	path, err := exec.LookPath(program.Executable)
+
+	if err != nil {
+		return err
+	}
+
+	if program.Credential != nil {
+		if err := switchCredential(program.Credential); err != nil {
+			return err
+		}
+	}
+
+	argv := append([]string{program.Executable}, program.Args...)
+
+	return syscall.Exec(path, argv, append(os.Environ(), program.Env...))
+}
+
+// terminate asks a process to exit
+func terminate(p *os.Process) error {
+	return p.Signal(syscall.SIGTERM)
+}
+
+// signalCode returns the shell exit code of a program killed by a signal
+func signalCode(err error) (int, bool) {
+	var exitErr *exec.ExitError
+
+	if !errors.As(err, &exitErr) {
+		return 0, false
+	}
+
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+
+	if !ok || !status.Signaled() {
+		return 0, false
+	}
+
+	return ExitSignal + int(status.Signal()), true
+}
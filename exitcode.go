@@ -0,0 +1,55 @@
+package waitfor
+
+import (
+	"errors"
+	"io/fs"
+	"os/exec"
+)
+
+// Exit codes returned by ExitCode, a program which exits with a non-zero status is reported with its own code
+const (
+	ExitOK = 0
+	// ExitUnavailable means resources did not become available, e.g. the attempts or the timeout are exhausted
+	ExitUnavailable = 1
+	// ExitUsage means invalid arguments or configuration
+	ExitUsage = 2
+	// ExitFailure is any other failure
+	ExitFailure = 3
+	// ExitCannotExecute means the program is found but cannot be executed
+	ExitCannotExecute = 126
+	// ExitNotFound means the program is not found
+	ExitNotFound = 127
+	// ExitSignal is added to the number of a signal which killed the program
+	ExitSignal = 128
+)
+
+// ExitCode maps an error of Test, Run, Exec and other methods to a process exit code,
+// so scripts can branch on the cause of a failure
+func ExitCode(err error) int {
+	var exitErr *ExitError
+
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &exitErr):
+		if exitErr.Code >= 0 {
+			return exitErr.Code
+		}
+
+		if code, ok := signalCode(exitErr); ok {
+			return code
+		}
+
+		return ExitFailure
+	case errors.Is(err, ErrInvalidArgument):
+		return ExitUsage
+	case errors.Is(err, ErrWait):
+		return ExitUnavailable
+	case errors.Is(err, exec.ErrNotFound), errors.Is(err, fs.ErrNotExist):
+		return ExitNotFound
+	case errors.Is(err, fs.ErrPermission):
+		return ExitCannotExecute
+	default:
+		return ExitFailure
+	}
+}
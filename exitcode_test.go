@@ -0,0 +1,54 @@
+package waitfor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "script")
+
+	assert.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\n"), 0o600))
+
+	r := New(useFileResource())
+	run := func(executable string, args ...string) error {
+		_, err := r.Run(context.Background(), Program{Executable: executable, Args: args})
+		return err
+	}
+
+	cases := []struct {
+		err  error
+		code int
+	}{
+		{nil, ExitOK},
+		{r.Test(context.Background(), []string{"file:///missing"}, WithAttempts(1), WithInterval(0)), ExitUnavailable},
+		{fmt.Errorf("post-start verification: %w", newWaitError([]ResourceFailure{{Err: os.ErrNotExist}})), ExitUnavailable},
+		{fmt.Errorf("%q: %w", "config", ErrInvalidArgument), ExitUsage},
+		{run("sh", "-c", "exit 4"), 4},
+		{run("sh", "-c", "kill -9 $$"), ExitSignal + 9},
+		{run("waitfor-missing-executable"), ExitNotFound},
+		{run(script), ExitCannotExecute},
+		{errors.New("unexpected"), ExitFailure},
+	}
+
+	for i, c := range cases {
+		assert.Equal(t, c.code, ExitCode(c.err), "%d: %v", i, c.err)
+	}
+}
+
+func TestExitCode_Documented(t *testing.T) {
+	readme, err := os.ReadFile("README.md")
+	assert.NoError(t, err)
+
+	for _, code := range []int{ExitOK, ExitUnavailable, ExitUsage, ExitFailure, ExitCannotExecute, ExitNotFound} {
+		assert.Contains(t, string(readme), fmt.Sprintf("| ``%d`` |", code))
+	}
+
+	assert.Contains(t, string(readme), fmt.Sprintf("| ``%d+n`` |", ExitSignal))
+}
@@ -0,0 +1,91 @@
+package waitfor
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type (
+	// PreExecHook is called once resources are available and before a program starts.
+	// Returning an error prevents the program from starting.
+	PreExecHook func(ctx context.Context, program Program, results []ResourceResult) error
+
+	// PostExecHook is called after a program exits with its execution error, if any
+	PostExecHook func(ctx context.Context, program Program, results []ResourceResult, err error)
+
+	// Attempt is an outcome of a single resource test
+	Attempt struct {
+		Resource string
+		// Number starts with 1 for the first test of a resource
+		Number   int
+		Duration time.Duration
+		Err      error
+		// Delay is the time until the next attempt, it is zero when the resource is not retried anymore
+		Delay time.Duration
+	}
+
+	// AttemptHook is called after every resource test attempt
+	AttemptHook func(ctx context.Context, attempt Attempt)
+
+	// ResultHook is called once a resource is available or it is not retried anymore
+	ResultHook func(ctx context.Context, result ResourceResult)
+
+	// StartHook is called before resources are tested
+	StartHook func(ctx context.Context, resources []string)
+
+	// CompletionHook is called once all resources are tested with their results and the test error, if any
+	CompletionHook func(ctx context.Context, results []ResourceResult, err error)
+)
+
+// testProgram tests program resources and runs pre-exec hooks
+func (r *Runner) testProgram(ctx context.Context, program Program, setters []Option) ([]ResourceResult, error) {
+	opts := newOptions(setters)
+	results, err := r.testAll(ctx, program.Resources, opts)
+
+	if err != nil {
+		return results, err
+	}
+
+	return results, r.beforeProgram(ctx, program, results, opts)
+}
+
+func (r *Runner) beforeProgram(ctx context.Context, program Program, results []ResourceResult, opts *Options) error {
+	for _, hook := range opts.preExecHooks {
+		if err := hook(ctx, program, results); err != nil {
+			return fmt.Errorf("pre-exec hook: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Runner) afterProgram(ctx context.Context, program Program, results []ResourceResult, err error, opts *Options) {
+	for _, hook := range opts.postExecHooks {
+		hook(ctx, program, results, err)
+	}
+}
+
+func (r *Runner) beforeTest(ctx context.Context, resources []string, opts *Options) {
+	for _, hook := range opts.startHooks {
+		hook(ctx, resources)
+	}
+}
+
+func (r *Runner) afterAttempt(ctx context.Context, attempt Attempt, opts *Options) {
+	for _, hook := range opts.attemptHooks {
+		hook(ctx, attempt)
+	}
+}
+
+func (r *Runner) afterResource(ctx context.Context, result ResourceResult, opts *Options) {
+	for _, hook := range opts.resultHooks {
+		hook(ctx, result)
+	}
+}
+
+func (r *Runner) afterTest(ctx context.Context, results []ResourceResult, err error, opts *Options) {
+	for _, hook := range opts.completionHooks {
+		hook(ctx, results, err)
+	}
+}
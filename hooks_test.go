@@ -0,0 +1,137 @@
+package waitfor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunner_Run_Hooks(t *testing.T) {
+	r := New()
+
+	var calls []string
+
+	_, err := r.Run(
+		context.Background(),
+		Program{Executable: "false"},
+		WithPreExecHook(func(_ context.Context, _ Program, _ []ResourceResult) error {
+			calls = append(calls, "pre")
+			return nil
+		}),
+		WithPostExecHook(func(_ context.Context, _ Program, _ []ResourceResult, err error) {
+			assert.Error(t, err)
+			calls = append(calls, "post")
+		}),
+	)
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{"pre", "post"}, calls)
+}
+
+func TestRunner_Run_PreExecHookError(t *testing.T) {
+	r := New()
+
+	_, err := r.Run(
+		context.Background(),
+		Program{Executable: "true"},
+		WithPreExecHook(func(_ context.Context, _ Program, _ []ResourceResult) error {
+			return errors.New("not now")
+		}),
+	)
+
+	assert.EqualError(t, err, "pre-exec hook: not now")
+}
+
+func TestRunner_Test_AttemptHooks(t *testing.T) {
+	r := New(useFlakyResource(&flakyResource{failures: 2}))
+
+	var mu sync.Mutex
+	var attempts []Attempt
+	var results []ResourceResult
+
+	err := r.Test(
+		context.Background(),
+		[]string{"flaky://localhost"},
+		WithInterval(0),
+		WithAttemptHook(func(_ context.Context, attempt Attempt) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			attempts = append(attempts, attempt)
+		}),
+		WithResultHook(func(_ context.Context, result ResourceResult) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			results = append(results, result)
+		}),
+	)
+
+	assert.NoError(t, err)
+	assert.Len(t, attempts, 3)
+
+	for i, attempt := range attempts {
+		assert.Equal(t, "flaky://localhost", attempt.Resource)
+		assert.Equal(t, i+1, attempt.Number)
+	}
+
+	assert.EqualError(t, attempts[0].Err, "attempt 1 failed")
+	assert.NoError(t, attempts[2].Err)
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+}
+
+func TestRunner_Test_AttemptHooks_Exhausted(t *testing.T) {
+	r := New(useFlakyResource(&flakyResource{failures: 5}))
+
+	var last Attempt
+
+	err := r.Test(
+		context.Background(),
+		[]string{"flaky://localhost"},
+		WithInterval(1),
+		WithAttempts(1),
+		WithAttemptHook(func(_ context.Context, attempt Attempt) {
+			if attempt.Number == 1 {
+				assert.Positive(t, attempt.Delay)
+			}
+
+			last = attempt
+		}),
+	)
+
+	assert.Error(t, err)
+	assert.Equal(t, 2, last.Number)
+	assert.Zero(t, last.Delay)
+}
+
+func TestRunner_Test_StartAndCompletionHooks(t *testing.T) {
+	r := New(useFlakyResource(&flakyResource{failures: 5}))
+
+	var calls int
+
+	err := r.Test(
+		context.Background(),
+		[]string{"flaky://localhost"},
+		WithInterval(0),
+		WithAttempts(1),
+		WithStartHook(func(_ context.Context, resources []string) {
+			assert.Zero(t, calls)
+			assert.Equal(t, []string{"flaky://localhost"}, resources)
+			calls++
+		}),
+		WithCompletionHook(func(_ context.Context, results []ResourceResult, err error) {
+			calls++
+
+			assert.Len(t, results, 1)
+			assert.Error(t, results[0].Err)
+			assert.ErrorContains(t, err, "attempt 2 failed")
+		}),
+	)
+
+	assert.Error(t, err)
+	assert.Equal(t, 2, calls)
+}
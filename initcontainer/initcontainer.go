@@ -0,0 +1,124 @@
+// Package initcontainer adapts waitfor to Kubernetes init containers.
+// It writes a readiness marker for the main containers once resources are available
+// and describes failed resources in the termination log shown by kubectl describe.
+package initcontainer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+const (
+	// DefaultTerminationLog is the default terminationMessagePath of a container
+	DefaultTerminationLog = "/dev/termination-log"
+
+	// maxTerminationMessage is the size of a termination message kept by Kubernetes
+	maxTerminationMessage = 4096
+)
+
+type (
+	options struct {
+		marker         string
+		terminationLog string
+		onError        func(err error)
+	}
+
+	Option func(opts *options)
+)
+
+// Set a path of a readiness marker file written when all resources are available,
+// e.g. on a volume shared with the main containers. A stale marker is removed on failure.
+func WithMarker(path string) Option {
+	return func(opts *options) {
+		opts.marker = path
+	}
+}
+
+// Set a path of the termination log, it defaults to DefaultTerminationLog, an empty path disables it
+func WithTerminationLog(path string) Option {
+	return func(opts *options) {
+		opts.terminationLog = path
+	}
+}
+
+// Set a handler of file errors, they are ignored by default
+func WithErrorHandler(handler func(err error)) Option {
+	return func(opts *options) {
+		opts.onError = handler
+	}
+}
+
+// OnCompletion returns a runner option writing the marker and the termination log when a test completes
+func OnCompletion(setters ...Option) waitfor.Option {
+	opts := &options{terminationLog: DefaultTerminationLog}
+
+	for _, setter := range setters {
+		setter(opts)
+	}
+
+	return waitfor.WithCompletionHook(func(_ context.Context, _ []waitfor.ResourceResult, err error) {
+		if err := complete(opts, err); err != nil && opts.onError != nil {
+			opts.onError(err)
+		}
+	})
+}
+
+func complete(opts *options, err error) error {
+	if err != nil {
+		return errors.Join(removeMarker(opts.marker), writeTerminationLog(opts.terminationLog, err))
+	}
+
+	if opts.marker == "" {
+		return nil
+	}
+
+	return os.WriteFile(opts.marker, []byte(time.Now().UTC().Format(time.RFC3339)+"\n"), 0o644)
+}
+
+func removeMarker(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func writeTerminationLog(path string, err error) error {
+	if path == "" {
+		return nil
+	}
+
+	return os.WriteFile(path, []byte(TerminationMessage(err)), 0o644)
+}
+
+// TerminationMessage describes failed resources one per line within the size kept by Kubernetes
+func TerminationMessage(err error) string {
+	var waitErr *waitfor.WaitError
+
+	message := err.Error()
+
+	if errors.As(err, &waitErr) {
+		lines := make([]string, 0, len(waitErr.Failed()))
+
+		for _, failure := range waitErr.Failed() {
+			lines = append(lines, failure.Error())
+		}
+
+		message = strings.Join(lines, "\n")
+	}
+
+	if len(message) > maxTerminationMessage {
+		message = message[:maxTerminationMessage]
+	}
+
+	return message
+}
@@ -0,0 +1,58 @@
+// Package awsconfig loads AWS SDK configuration for resources from URL query parameters
+package awsconfig
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/go-waitfor/waitfor"
+)
+
+// Params are a region and a custom endpoint of emulators like LocalStack or MinIO
+type Params struct {
+	Region   string
+	Endpoint string
+}
+
+// FromQuery reads options like ?region=eu-west-1&endpoint=http://localhost:4566
+func FromQuery(q url.Values) (Params, error) {
+	p := Params{
+		Region:   q.Get("region"),
+		Endpoint: q.Get("endpoint"),
+	}
+
+	if p.Endpoint != "" {
+		if e, err := url.Parse(p.Endpoint); err != nil || e.Host == "" {
+			return Params{}, fmt.Errorf("%q: invalid url: %w", "endpoint", waitfor.ErrInvalidArgument)
+		}
+	}
+
+	return p, nil
+}
+
+// Load resolves credentials with the default chain. Requests are not retried by the SDK,
+// failed tests are retried by the runner.
+func (p Params) Load(ctx context.Context) (aws.Config, error) {
+	var opts []func(*config.LoadOptions) error
+
+	if p.Region != "" {
+		opts = append(opts, config.WithRegion(p.Region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+
+	if err != nil {
+		return cfg, err
+	}
+
+	if p.Endpoint != "" {
+		cfg.BaseEndpoint = aws.String(p.Endpoint)
+	}
+
+	cfg.RetryMaxAttempts = 1
+
+	return cfg, nil
+}
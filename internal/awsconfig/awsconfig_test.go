@@ -0,0 +1,32 @@
+package awsconfig
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParams_Load(t *testing.T) {
+	t.Setenv("AWS_CONFIG_FILE", "/dev/null")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/dev/null")
+
+	p, err := FromQuery(url.Values{"region": {"eu-west-1"}, "endpoint": {"http://localhost:4566"}})
+
+	assert.NoError(t, err)
+
+	cfg, err := p.Load(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "eu-west-1", cfg.Region)
+	assert.Equal(t, "http://localhost:4566", *cfg.BaseEndpoint)
+	assert.Equal(t, 1, cfg.RetryMaxAttempts)
+}
+
+func TestFromQuery_InvalidArgument(t *testing.T) {
+	_, err := FromQuery(url.Values{"endpoint": {"localhost:4566"}})
+
+	assert.ErrorIs(t, err, waitfor.ErrInvalidArgument)
+}
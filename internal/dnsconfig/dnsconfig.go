@@ -0,0 +1,31 @@
+// Package dnsconfig builds DNS resolvers from URL query parameters
+package dnsconfig
+
+import (
+	"context"
+	"net"
+	"net/url"
+)
+
+// FromQuery returns a resolver querying the nameserver parameter, e.g. ?nameserver=10.0.0.2:53,
+// or the system resolver if it is not set. The port defaults to 53.
+func FromQuery(q url.Values) *net.Resolver {
+	nameserver := q.Get("nameserver")
+
+	if nameserver == "" {
+		return net.DefaultResolver
+	}
+
+	if _, _, err := net.SplitHostPort(nameserver); err != nil {
+		nameserver = net.JoinHostPort(nameserver, "53")
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+
+			return d.DialContext(ctx, network, nameserver)
+		},
+	}
+}
@@ -0,0 +1,136 @@
+// Package kubeclient is a minimal Kubernetes API client used by resources inside a cluster or behind kubectl proxy
+package kubeclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+// ErrNotFound is returned when a requested object does not exist
+var ErrNotFound = errors.New("not found")
+
+// serviceAccountDir holds credentials mounted into pods
+var serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// Client reads objects from the API server
+type Client struct {
+	base   *url.URL
+	client *http.Client
+	// tokenFile is read on every request because projected service account tokens are rotated
+	tokenFile string
+}
+
+// FromQuery creates a client for the endpoint parameter, e.g. ?endpoint=http://127.0.0.1:8001 for kubectl proxy.
+// Without it the in-cluster API server and the pod service account are used.
+func FromQuery(q url.Values) (*Client, error) {
+	if endpoint := q.Get("endpoint"); endpoint != "" {
+		base, err := url.Parse(endpoint)
+
+		if err != nil || base.Host == "" {
+			return nil, fmt.Errorf("%q: invalid url: %w", "endpoint", waitfor.ErrInvalidArgument)
+		}
+
+		return &Client{base: base, client: http.DefaultClient}, nil
+	}
+
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("%q: required outside of a cluster: %w", "endpoint", waitfor.ErrInvalidArgument)
+	}
+
+	ca, err := os.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no certificates in %s", filepath.Join(serviceAccountDir, "ca.crt"))
+	}
+
+	return &Client{
+		base: &url.URL{Scheme: "https", Host: net.JoinHostPort(host, port)},
+		client: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{RootCAs: pool},
+			},
+		},
+		tokenFile: filepath.Join(serviceAccountDir, "token"),
+	}, nil
+}
+
+// Namespace returns the namespace parameter, the namespace of the current pod or "default"
+func Namespace(q url.Values) string {
+	if ns := q.Get("namespace"); ns != "" {
+		return ns
+	}
+
+	if ns, err := os.ReadFile(filepath.Join(serviceAccountDir, "namespace")); err == nil {
+		return strings.TrimSpace(string(ns))
+	}
+
+	return "default"
+}
+
+// Get decodes an object at a given API path, e.g. /apis/batch/v1/namespaces/default/jobs/migrate
+func (c *Client) Get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.base.JoinPath(path).String(), nil)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if c.tokenFile != "" {
+		token, err := os.ReadFile(c.tokenFile)
+
+		if err != nil {
+			return err
+		}
+
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	res, err := c.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return json.NewDecoder(res.Body).Decode(v)
+	case http.StatusNotFound:
+		return fmt.Errorf("%s: %w", path, ErrNotFound)
+	}
+
+	// failures are described by a Status object
+	var status struct {
+		Message string `json:"message"`
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&status); err != nil || status.Message == "" {
+		return fmt.Errorf("%s: unexpected status code %d", path, res.StatusCode)
+	}
+
+	return fmt.Errorf("%s: %s", path, status.Message)
+}
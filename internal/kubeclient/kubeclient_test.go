@@ -0,0 +1,80 @@
+package kubeclient
+
+import (
+	"context"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Get(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("Authorization") != "Bearer secret":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"kind":"Status","message":"Unauthorized"}`))
+		case r.URL.Path == "/api/v1/namespaces/apps":
+			_, _ = w.Write([]byte(`{"metadata":{"name":"apps"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	defaultDir := serviceAccountDir
+	serviceAccountDir = dir
+
+	t.Cleanup(func() {
+		serviceAccountDir = defaultDir
+	})
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "ca.crt"), ca, 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte("secret\n"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "namespace"), []byte("apps"), 0o600))
+
+	addr := srv.Listener.Addr().(*net.TCPAddr)
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", addr.IP.String())
+	t.Setenv("KUBERNETES_SERVICE_PORT", strconv.Itoa(addr.Port))
+
+	c, err := FromQuery(url.Values{})
+	assert.NoError(t, err)
+
+	var ns struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+	}
+
+	assert.NoError(t, c.Get(context.Background(), "/api/v1/namespaces/apps", &ns))
+	assert.Equal(t, "apps", ns.Metadata.Name)
+	assert.ErrorIs(t, c.Get(context.Background(), "/api/v1/namespaces/web", &ns), ErrNotFound)
+
+	assert.Equal(t, "apps", Namespace(url.Values{}))
+	assert.Equal(t, "web", Namespace(url.Values{"namespace": {"web"}}))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte("expired"), 0o600))
+	assert.EqualError(t, c.Get(context.Background(), "/api/v1/namespaces/apps", &ns), "/api/v1/namespaces/apps: Unauthorized")
+}
+
+func TestFromQuery_InvalidArgument(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+
+	for _, q := range []url.Values{{}, {"endpoint": {"localhost"}}} {
+		_, err := FromQuery(q)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, q.Encode())
+	}
+}
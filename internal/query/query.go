@@ -0,0 +1,77 @@
+// Package query parses resource options from URL query parameters
+package query
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+// Duration returns a duration parameter or a given default value if it is not set
+func Duration(q url.Values, key string, def time.Duration) (time.Duration, error) {
+	value := q.Get(key)
+
+	if value == "" {
+		return def, nil
+	}
+
+	d, err := time.ParseDuration(value)
+
+	if err != nil {
+		return 0, invalid(key, err)
+	}
+
+	return d, nil
+}
+
+// Int returns an integer parameter or a given default value if it is not set
+func Int(q url.Values, key string, def int) (int, error) {
+	value := q.Get(key)
+
+	if value == "" {
+		return def, nil
+	}
+
+	i, err := strconv.Atoi(value)
+
+	if err != nil {
+		return 0, invalid(key, err)
+	}
+
+	return i, nil
+}
+
+// Bool returns a boolean parameter or a given default value if it is not set
+func Bool(q url.Values, key string, def bool) (bool, error) {
+	value := q.Get(key)
+
+	if value == "" {
+		return def, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+
+	if err != nil {
+		return false, invalid(key, err)
+	}
+
+	return b, nil
+}
+
+// String returns a string parameter or a given default value if it is not set
+func String(q url.Values, key string, def string) string {
+	value := q.Get(key)
+
+	if value == "" {
+		return def
+	}
+
+	return value
+}
+
+func invalid(key string, err error) error {
+	return fmt.Errorf("%q: %s: %w", key, err, waitfor.ErrInvalidArgument)
+}
@@ -0,0 +1,76 @@
+// Package sshconfig builds SSH client configuration from resource URLs
+package sshconfig
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// FromURL creates a config authenticating with the url password or a private key file,
+// e.g. ssh://user@host?key=id_ed25519&knownHosts=known_hosts. Host keys are checked against
+// ~/.ssh/known_hosts unless another file is set or insecure=true disables the check.
+func FromURL(u *url.URL, timeout time.Duration) (*ssh.ClientConfig, error) {
+	q := u.Query()
+	cfg := &ssh.ClientConfig{Timeout: timeout}
+
+	if u.User != nil {
+		cfg.User = u.User.Username()
+
+		if password, ok := u.User.Password(); ok {
+			cfg.Auth = append(cfg.Auth, ssh.Password(password))
+		}
+	}
+
+	if path := q.Get("key"); path != "" {
+		pem, err := os.ReadFile(path)
+
+		if err != nil {
+			return nil, fmt.Errorf("%q: %s: %w", "key", err, waitfor.ErrInvalidArgument)
+		}
+
+		signer, err := ssh.ParsePrivateKey(pem)
+
+		if err != nil {
+			return nil, fmt.Errorf("%q: %s: %w", "key", err, waitfor.ErrInvalidArgument)
+		}
+
+		cfg.Auth = append(cfg.Auth, ssh.PublicKeys(signer))
+	}
+
+	insecure, err := query.Bool(q, "insecure", false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if insecure {
+		cfg.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+		return cfg, nil
+	}
+
+	path := q.Get("knownHosts")
+
+	if path == "" {
+		home, err := os.UserHomeDir()
+
+		if err != nil {
+			return nil, fmt.Errorf("%q: %s: %w", "knownHosts", err, waitfor.ErrInvalidArgument)
+		}
+
+		path = filepath.Join(home, ".ssh", "known_hosts")
+	}
+
+	if cfg.HostKeyCallback, err = knownhosts.New(path); err != nil {
+		return nil, fmt.Errorf("%q: %s: %w", "knownHosts", err, waitfor.ErrInvalidArgument)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,79 @@
+// Package tlsconfig builds TLS client configuration from URL query parameters
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+// Params are TLS options, resources supporting TLS remove them from target URLs
+var Params = []string{"ca", "cert", "key", "insecure", "serverName"}
+
+// FromQuery applies options like ?ca=ca.pem&cert=client.pem&key=client.key&insecure=true&serverName=db
+// on top of a base config. It returns the base config if no options are set.
+func FromQuery(q url.Values, base *tls.Config) (*tls.Config, error) {
+	if !hasParams(q) {
+		return base, nil
+	}
+
+	cfg := &tls.Config{}
+
+	if base != nil {
+		cfg = base.Clone()
+	}
+
+	if path := q.Get("ca"); path != "" {
+		pem, err := os.ReadFile(path)
+
+		if err != nil {
+			return nil, fmt.Errorf("%q: %s: %w", "ca", err, waitfor.ErrInvalidArgument)
+		}
+
+		pool := x509.NewCertPool()
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%q: no certificates found in %s: %w", "ca", path, waitfor.ErrInvalidArgument)
+		}
+
+		cfg.RootCAs = pool
+	}
+
+	cert, key := q.Get("cert"), q.Get("key")
+
+	if cert != "" || key != "" {
+		pair, err := tls.LoadX509KeyPair(cert, key)
+
+		if err != nil {
+			return nil, fmt.Errorf("%q: %s: %w", "cert", err, waitfor.ErrInvalidArgument)
+		}
+
+		cfg.Certificates = []tls.Certificate{pair}
+	}
+
+	insecure, err := query.Bool(q, "insecure", cfg.InsecureSkipVerify)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.InsecureSkipVerify = insecure
+	cfg.ServerName = query.String(q, "serverName", cfg.ServerName)
+
+	return cfg, nil
+}
+
+func hasParams(q url.Values) bool {
+	for _, p := range Params {
+		if q.Has(p) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,49 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromQuery(t *testing.T) {
+	base := &tls.Config{ServerName: "base"}
+
+	cfg, err := FromQuery(url.Values{}, base)
+
+	assert.NoError(t, err)
+	assert.Same(t, base, cfg)
+
+	cfg, err = FromQuery(url.Values{"insecure": {"true"}, "serverName": {"db"}}, base)
+
+	assert.NoError(t, err)
+	assert.True(t, cfg.InsecureSkipVerify)
+	assert.Equal(t, "db", cfg.ServerName)
+	assert.Equal(t, "base", base.ServerName)
+}
+
+func TestFromQuery_CA(t *testing.T) {
+	srv := httptest.NewTLSServer(nil)
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	assert.NoError(t, os.WriteFile(path, data, 0600))
+
+	cfg, err := FromQuery(url.Values{"ca": {path}}, nil)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, cfg.RootCAs)
+
+	_, err = FromQuery(url.Values{"ca": {filepath.Join(t.TempDir(), "missing.pem")}}, nil)
+
+	assert.ErrorIs(t, err, waitfor.ErrInvalidArgument)
+}
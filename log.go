@@ -0,0 +1,8 @@
+package waitfor
+
+import (
+	"log/slog"
+)
+
+// discardLogger is used when no logger is configured
+var discardLogger = slog.New(slog.DiscardHandler)
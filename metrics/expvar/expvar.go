@@ -0,0 +1,115 @@
+// Package expvar exposes counters of resource availability tests for debugging stuck processes
+package expvar
+
+import (
+	"context"
+	stdexpvar "expvar"
+	"net/http"
+	"net/url"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+// Vars tracks the number of resources being tested, attempts and failed attempts per scheme
+// and the last error of every resource which is not available yet
+type Vars struct {
+	root       *stdexpvar.Map
+	active     *stdexpvar.Int
+	attempts   *stdexpvar.Map
+	failures   *stdexpvar.Map
+	lastErrors *stdexpvar.Map
+}
+
+var _ stdexpvar.Var = (*Vars)(nil)
+
+func New() *Vars {
+	v := &Vars{
+		root:       new(stdexpvar.Map).Init(),
+		active:     new(stdexpvar.Int),
+		attempts:   new(stdexpvar.Map).Init(),
+		failures:   new(stdexpvar.Map).Init(),
+		lastErrors: new(stdexpvar.Map).Init(),
+	}
+
+	v.root.Set("active", v.active)
+	v.root.Set("attempts", v.attempts)
+	v.root.Set("failures", v.failures)
+	v.root.Set("last_errors", v.lastErrors)
+
+	return v
+}
+
+// Publish registers the vars in the expvar package under a given name, e.g. waitfor.
+// Like expvar.Publish it panics if the name is already registered.
+func (v *Vars) Publish(name string) {
+	stdexpvar.Publish(name, v)
+}
+
+// String returns the vars as a JSON object
+func (v *Vars) String() string {
+	return v.root.String()
+}
+
+// Handler serves the vars as a JSON object without registering them globally
+func (v *Vars) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write([]byte(v.String()))
+	})
+}
+
+// Option returns a runner option updating the vars on every test
+func (v *Vars) Option() waitfor.Option {
+	return func(opts *waitfor.Options) {
+		waitfor.WithStartHook(v.start)(opts)
+		waitfor.WithAttemptHook(v.attempt)(opts)
+		waitfor.WithResultHook(v.result)(opts)
+	}
+}
+
+func (v *Vars) start(_ context.Context, resources []string) {
+	v.active.Add(int64(len(resources)))
+}
+
+func (v *Vars) attempt(_ context.Context, attempt waitfor.Attempt) {
+	scheme, resource := describe(attempt.Resource)
+
+	v.attempts.Add(scheme, 1)
+
+	if attempt.Err == nil {
+		v.lastErrors.Delete(resource)
+		return
+	}
+
+	v.failures.Add(scheme, 1)
+
+	msg := new(stdexpvar.String)
+	msg.Set(attempt.Err.Error())
+
+	v.lastErrors.Set(resource, msg)
+}
+
+func (v *Vars) result(_ context.Context, result waitfor.ResourceResult) {
+	v.active.Add(-1)
+
+	// resources which are not resolved are never attempted
+	if result.Err != nil && result.Attempts == 0 {
+		_, resource := describe(result.Resource)
+
+		msg := new(stdexpvar.String)
+		msg.Set(result.Err.Error())
+
+		v.lastErrors.Set(resource, msg)
+	}
+}
+
+// describe returns the scheme and redacted location of a resource
+func describe(resource string) (string, string) {
+	var scheme string
+
+	if u, err := url.Parse(resource); err == nil {
+		scheme = u.Scheme
+	}
+
+	return scheme, waitfor.Redact(resource)
+}
@@ -0,0 +1,77 @@
+package expvar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	stdexpvar "expvar"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyResource fails a given number of tests before it becomes available
+type flakyResource struct {
+	failures int
+}
+
+func (f *flakyResource) Test(_ context.Context) error {
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("connection refused")
+	}
+
+	return nil
+}
+
+func newRunner() *waitfor.Runner {
+	return waitfor.New(waitfor.ResourceConfig{
+		Scheme: []string{"flaky"},
+		Factory: func(u *url.URL) (waitfor.Resource, error) {
+			if u.Host == "down" {
+				return &flakyResource{failures: 100}, nil
+			}
+
+			return &flakyResource{failures: 1}, nil
+		},
+	})
+}
+
+type snapshot struct {
+	Active     int               `json:"active"`
+	Attempts   map[string]int    `json:"attempts"`
+	Failures   map[string]int    `json:"failures"`
+	LastErrors map[string]string `json:"last_errors"`
+}
+
+func TestVars(t *testing.T) {
+	v := New()
+
+	_ = newRunner().Test(
+		context.Background(),
+		[]string{"flaky://user:secret@up", "flaky://down", "unknown://localhost"},
+		waitfor.WithInterval(0),
+		waitfor.WithAttempts(1),
+		v.Option(),
+	)
+
+	rec := httptest.NewRecorder()
+	v.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/waitfor", nil))
+
+	var s snapshot
+
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &s))
+	assert.Equal(t, 0, s.Active)
+	assert.Equal(t, map[string]int{"flaky": 4}, s.Attempts)
+	assert.Equal(t, map[string]int{"flaky": 3}, s.Failures)
+	assert.Equal(t, "connection refused", s.LastErrors["flaky://down"])
+	assert.Contains(t, s.LastErrors["unknown://localhost"], "not found")
+	assert.NotContains(t, s.LastErrors, "flaky://user:xxxxx@up")
+
+	v.Publish("waitfor_test")
+
+	assert.Equal(t, v.String(), stdexpvar.Get("waitfor_test").String())
+}
@@ -0,0 +1,108 @@
+// Package prometheus provides Prometheus metrics of resource availability tests
+package prometheus
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/go-waitfor/waitfor"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// Namespace prefixes names of all metrics
+const Namespace = "waitfor"
+
+// labels of all metrics, a resource is identified by its scheme and host to keep
+// cardinality bounded and its path, options and credentials out of the series
+var labels = []string{"scheme", "host"}
+
+// Metrics counts test attempts and failures and observes the time until a resource is available,
+// it is a prometheus.Collector and is meant to be registered once and shared by runs
+type Metrics struct {
+	attempts    *prom.CounterVec
+	failures    *prom.CounterVec
+	giveUps     *prom.CounterVec
+	timeToReady *prom.HistogramVec
+}
+
+var _ prom.Collector = (*Metrics)(nil)
+
+func New() *Metrics {
+	return &Metrics{
+		attempts: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: Namespace,
+			Name:      "attempts_total",
+			Help:      "Number of resource test attempts.",
+		}, labels),
+		failures: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: Namespace,
+			Name:      "attempt_failures_total",
+			Help:      "Number of failed resource test attempts.",
+		}, labels),
+		giveUps: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: Namespace,
+			Name:      "resource_failures_total",
+			Help:      "Number of resources which did not become available.",
+		}, labels),
+		timeToReady: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "time_to_ready_seconds",
+			Help:      "Time until a resource became available.",
+			Buckets:   []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+		}, labels),
+	}
+}
+
+// Option returns a runner option recording metrics of every test
+func (m *Metrics) Option() waitfor.Option {
+	return func(opts *waitfor.Options) {
+		waitfor.WithAttemptHook(m.observeAttempt)(opts)
+		waitfor.WithResultHook(m.observeResult)(opts)
+	}
+}
+
+func (m *Metrics) Describe(ch chan<- *prom.Desc) {
+	m.attempts.Describe(ch)
+	m.failures.Describe(ch)
+	m.giveUps.Describe(ch)
+	m.timeToReady.Describe(ch)
+}
+
+func (m *Metrics) Collect(ch chan<- prom.Metric) {
+	m.attempts.Collect(ch)
+	m.failures.Collect(ch)
+	m.giveUps.Collect(ch)
+	m.timeToReady.Collect(ch)
+}
+
+func (m *Metrics) observeAttempt(_ context.Context, attempt waitfor.Attempt) {
+	values := labelValues(attempt.Resource)
+
+	m.attempts.WithLabelValues(values...).Inc()
+
+	if attempt.Err != nil {
+		m.failures.WithLabelValues(values...).Inc()
+	}
+}
+
+func (m *Metrics) observeResult(_ context.Context, result waitfor.ResourceResult) {
+	values := labelValues(result.Resource)
+
+	if result.Err != nil {
+		m.giveUps.WithLabelValues(values...).Inc()
+		return
+	}
+
+	m.timeToReady.WithLabelValues(values...).Observe(result.Duration.Seconds())
+}
+
+// labelValues returns the scheme and host of a resource
+func labelValues(resource string) []string {
+	u, err := url.Parse(resource)
+
+	if err != nil {
+		return []string{"", ""}
+	}
+
+	return []string{u.Scheme, u.Host}
+}
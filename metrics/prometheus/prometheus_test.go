@@ -0,0 +1,72 @@
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyResource fails a given number of tests before it becomes available
+type flakyResource struct {
+	failures int
+}
+
+func (f *flakyResource) Test(_ context.Context) error {
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("not ready")
+	}
+
+	return nil
+}
+
+func newRunner() *waitfor.Runner {
+	return waitfor.New(waitfor.ResourceConfig{
+		Scheme: []string{"flaky"},
+		Factory: func(u *url.URL) (waitfor.Resource, error) {
+			if u.Host == "down" {
+				return &flakyResource{failures: 100}, nil
+			}
+
+			return &flakyResource{failures: 2}, nil
+		},
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	m := New()
+	registry := prom.NewRegistry()
+
+	assert.NoError(t, registry.Register(m))
+
+	err := newRunner().Test(
+		context.Background(),
+		[]string{"flaky://user:secret@up", "flaky://down"},
+		waitfor.WithInterval(0),
+		waitfor.WithAttempts(3),
+		m.Option(),
+	)
+
+	assert.Error(t, err)
+
+	up := []string{"flaky", "up"}
+	down := []string{"flaky", "down"}
+
+	assert.Equal(t, 3.0, testutil.ToFloat64(m.attempts.WithLabelValues(up...)))
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.failures.WithLabelValues(up...)))
+	assert.Equal(t, 4.0, testutil.ToFloat64(m.attempts.WithLabelValues(down...)))
+	assert.Equal(t, 4.0, testutil.ToFloat64(m.failures.WithLabelValues(down...)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.giveUps.WithLabelValues(down...)))
+	assert.Equal(t, 1, testutil.CollectAndCount(m, "waitfor_time_to_ready_seconds"))
+
+	families, err := registry.Gather()
+
+	assert.NoError(t, err)
+	assert.Len(t, families, 4)
+}
@@ -0,0 +1,94 @@
+package prometheus
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// pushTimeout bounds a push which is done even if the run is cancelled
+const pushTimeout = 10 * time.Second
+
+type (
+	pushOptions struct {
+		grouping map[string]string
+		client   push.HTTPDoer
+		onError  func(err error)
+	}
+
+	PushOption func(opts *pushOptions)
+
+	// Pushgateway pushes a summary of every run to a Prometheus Pushgateway,
+	// which suits one-shot runs that are not scraped, e.g. init containers.
+	// The summary replaces metrics of a previous run in the same group.
+	Pushgateway struct {
+		url  string
+		job  string
+		opts *pushOptions
+	}
+)
+
+// Add a grouping label, e.g. an instance or a pod name
+func WithGrouping(name, value string) PushOption {
+	return func(opts *pushOptions) {
+		opts.grouping[name] = value
+	}
+}
+
+// Set a custom HTTP client
+func WithClient(client push.HTTPDoer) PushOption {
+	return func(opts *pushOptions) {
+		opts.client = client
+	}
+}
+
+// Set a handler of push errors, they are ignored by default
+func WithErrorHandler(handler func(err error)) PushOption {
+	return func(opts *pushOptions) {
+		opts.onError = handler
+	}
+}
+
+// NewPushgateway creates a pusher to a given Pushgateway url and job name
+func NewPushgateway(url, job string, setters ...PushOption) *Pushgateway {
+	opts := &pushOptions{grouping: make(map[string]string)}
+
+	for _, setter := range setters {
+		setter(opts)
+	}
+
+	return &Pushgateway{
+		url:  url,
+		job:  job,
+		opts: opts,
+	}
+}
+
+// Option returns a runner option pushing a summary once all resources are tested
+func (p *Pushgateway) Option() waitfor.Option {
+	return waitfor.WithCompletionHook(func(ctx context.Context, results []waitfor.ResourceResult, _ error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), pushTimeout)
+		defer cancel()
+
+		if err := p.Push(ctx, results); err != nil && p.opts.onError != nil {
+			p.opts.onError(err)
+		}
+	})
+}
+
+// Push sends ready status and wait duration of every resource
+func (p *Pushgateway) Push(ctx context.Context, results []waitfor.ResourceResult) error {
+	pusher := push.New(p.url, p.job).Gatherer(summary(results))
+
+	for name, value := range p.opts.grouping {
+		pusher = pusher.Grouping(name, value)
+	}
+
+	if p.opts.client != nil {
+		pusher = pusher.Client(p.opts.client)
+	}
+
+	return pusher.PushContext(ctx)
+}
@@ -0,0 +1,51 @@
+package prometheus
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushgateway(t *testing.T) {
+	var method, path string
+	var body []byte
+
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	var pushErr error
+
+	p := NewPushgateway(srv.URL, "init", WithGrouping("pod", "app-0"), WithErrorHandler(func(err error) { pushErr = err }))
+	err := newRunner().Test(context.Background(), []string{"flaky://up", "flaky://down"}, waitfor.WithInterval(0), waitfor.WithAttempts(1), p.Option())
+
+	assert.Error(t, err)
+	assert.NoError(t, pushErr)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/metrics/job/init/pod/app-0", path)
+	assert.Contains(t, string(body), "waitfor_resource_ready")
+	assert.Contains(t, string(body), "waitfor_resource_wait_duration_seconds")
+	assert.Contains(t, string(body), "down")
+}
+
+func TestPushgateway_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	var pushErr error
+
+	p := NewPushgateway(srv.URL, "init", WithErrorHandler(func(err error) { pushErr = err }))
+	err := newRunner().Test(context.Background(), []string{"flaky://up"}, waitfor.WithInterval(0), p.Option())
+
+	assert.NoError(t, err)
+	assert.Error(t, pushErr)
+}
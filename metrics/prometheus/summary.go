@@ -0,0 +1,74 @@
+package prometheus
+
+import (
+	"math"
+	"strings"
+
+	"github.com/go-waitfor/waitfor"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// summary returns ready status and wait duration of every resource of a run and the time of its completion
+func summary(results []waitfor.ResourceResult) *prom.Registry {
+	ready := prom.NewGaugeVec(prom.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "resource_ready",
+		Help:      "Whether a resource became available.",
+	}, labels)
+	duration := prom.NewGaugeVec(prom.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "resource_wait_duration_seconds",
+		Help:      "Time spent waiting for a resource.",
+	}, labels)
+	completion := prom.NewGauge(prom.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "last_completion_timestamp_seconds",
+		Help:      "Time when the last run completed.",
+	})
+
+	// resources sharing a scheme and host are summarized together: ready when all of them are,
+	// after the longest wait
+	type series struct {
+		values   []string
+		ready    bool
+		duration float64
+	}
+
+	var order []string
+	merged := make(map[string]*series)
+
+	for _, res := range results {
+		values := labelValues(res.Resource)
+		key := strings.Join(values, "\x00")
+
+		s, ok := merged[key]
+
+		if !ok {
+			s = &series{values: values, ready: true}
+			merged[key] = s
+			order = append(order, key)
+		}
+
+		s.ready = s.ready && res.Err == nil
+		s.duration = math.Max(s.duration, res.Duration.Seconds())
+	}
+
+	for _, key := range order {
+		s := merged[key]
+
+		if s.ready {
+			ready.WithLabelValues(s.values...).Set(1)
+		} else {
+			ready.WithLabelValues(s.values...).Set(0)
+		}
+
+		duration.WithLabelValues(s.values...).Set(s.duration)
+	}
+
+	completion.SetToCurrentTime()
+
+	registry := prom.NewRegistry()
+	registry.MustRegister(ready, duration, completion)
+
+	return registry
+}
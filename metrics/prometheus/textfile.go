@@ -0,0 +1,46 @@
+package prometheus
+
+import (
+	"context"
+
+	"github.com/go-waitfor/waitfor"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+type (
+	textfileOptions struct {
+		onError func(err error)
+	}
+
+	// TextfileSetting configures TextfileOption
+	TextfileSetting func(opts *textfileOptions)
+)
+
+// Set a handler of textfile write errors, they are ignored by default
+func WithTextfileErrorHandler(handler func(err error)) TextfileSetting {
+	return func(opts *textfileOptions) {
+		opts.onError = handler
+	}
+}
+
+// WriteTextfile writes a summary of a run in the text exposition format for the node_exporter textfile collector.
+// The file is replaced atomically and its name must end with .prom to be collected.
+func WriteTextfile(path string, results []waitfor.ResourceResult) error {
+	return prom.WriteToTextfile(path, summary(results))
+}
+
+// TextfileOption returns a runner option writing a summary once all resources are tested,
+// write errors are ignored unless WithTextfileErrorHandler is set
+func TextfileOption(path string, setters ...TextfileSetting) waitfor.Option {
+	opts := new(textfileOptions)
+
+	for _, setter := range setters {
+		setter(opts)
+	}
+
+	return waitfor.WithCompletionHook(func(_ context.Context, results []waitfor.ResourceResult, _ error) {
+		if err := WriteTextfile(path, results); err != nil && opts.onError != nil {
+			opts.onError(err)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package prometheus
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextfileOption(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "waitfor.prom")
+
+	var writeErr error
+
+	err := newRunner().Test(context.Background(), []string{"flaky://up", "flaky://down"}, waitfor.WithInterval(0), waitfor.WithAttempts(2), TextfileOption(path, WithTextfileErrorHandler(func(err error) { writeErr = err })))
+
+	assert.Error(t, err)
+	assert.NoError(t, writeErr)
+
+	data, err := os.ReadFile(path)
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "# TYPE waitfor_resource_ready gauge\n")
+	assert.Contains(t, string(data), `waitfor_resource_ready{host="down",scheme="flaky"} 0`)
+	assert.Contains(t, string(data), `waitfor_resource_ready{host="up",scheme="flaky"} 1`)
+	assert.Contains(t, string(data), "waitfor_resource_wait_duration_seconds{")
+	assert.Contains(t, string(data), "waitfor_last_completion_timestamp_seconds ")
+
+	err = newRunner().Test(context.Background(), []string{"flaky://up"}, waitfor.WithInterval(0), TextfileOption(filepath.Join(path, "missing", "waitfor.prom"), WithTextfileErrorHandler(func(err error) { writeErr = err })))
+
+	assert.NoError(t, err)
+	assert.Error(t, writeErr)
+}
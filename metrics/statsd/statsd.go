@@ -0,0 +1,152 @@
+// Package statsd sends metrics of resource availability tests to a StatsD or DogStatsD server
+package statsd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+const (
+	DefaultPrefix = "waitfor"
+	DefaultPort   = "8125"
+)
+
+// replacers drop characters with a special meaning in the StatsD protocol
+var (
+	nameReplacer = strings.NewReplacer(".", "_", ":", "_", "|", "_", "@", "_")
+	tagReplacer  = strings.NewReplacer(",", "_", "|", "_", "#", "_")
+)
+
+type (
+	options struct {
+		prefix string
+		tags   bool
+	}
+
+	Option func(opts *options)
+
+	// StatsD counts test attempts and failures and times resources until they are available.
+	// Plain StatsD metric names end with the resource scheme, e.g. waitfor.attempts.postgres,
+	// DogStatsD metrics are tagged with the scheme and host instead.
+	StatsD struct {
+		conn   net.Conn
+		prefix string
+		tags   bool
+	}
+)
+
+// Set a custom metric name prefix
+func WithPrefix(prefix string) Option {
+	return func(opts *options) {
+		opts.prefix = prefix
+	}
+}
+
+// Send DogStatsD tags instead of appending the scheme to metric names
+func WithTags() Option {
+	return func(opts *options) {
+		opts.tags = true
+	}
+}
+
+// New creates a client sending metrics over UDP to a given address, e.g. localhost:8125
+func New(addr string, setters ...Option) (*StatsD, error) {
+	opts := &options{prefix: DefaultPrefix}
+
+	for _, setter := range setters {
+		setter(opts)
+	}
+
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, DefaultPort)
+	}
+
+	conn, err := net.Dial("udp", addr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &StatsD{
+		conn:   conn,
+		prefix: opts.prefix,
+		tags:   opts.tags,
+	}, nil
+}
+
+// FromEnv creates a DogStatsD client from DD_AGENT_HOST and DD_DOGSTATSD_PORT
+// or a plain StatsD client from STATSD_HOST and STATSD_PORT environment variables
+func FromEnv(setters ...Option) (*StatsD, error) {
+	if host := os.Getenv("DD_AGENT_HOST"); host != "" {
+		return New(net.JoinHostPort(host, env("DD_DOGSTATSD_PORT", DefaultPort)), append([]Option{WithTags()}, setters...)...)
+	}
+
+	if host := os.Getenv("STATSD_HOST"); host != "" {
+		return New(net.JoinHostPort(host, env("STATSD_PORT", DefaultPort)), setters...)
+	}
+
+	return nil, fmt.Errorf("%q: no statsd server is configured: %w", "env", waitfor.ErrInvalidArgument)
+}
+
+// Option returns a runner option sending metrics of every test
+func (s *StatsD) Option() waitfor.Option {
+	return func(opts *waitfor.Options) {
+		waitfor.WithAttemptHook(s.observeAttempt)(opts)
+		waitfor.WithResultHook(s.observeResult)(opts)
+	}
+}
+
+// Close closes the connection
+func (s *StatsD) Close() error {
+	return s.conn.Close()
+}
+
+func (s *StatsD) observeAttempt(_ context.Context, attempt waitfor.Attempt) {
+	s.send("attempts", attempt.Resource, "1|c")
+
+	if attempt.Err != nil {
+		s.send("attempt_failures", attempt.Resource, "1|c")
+	}
+}
+
+func (s *StatsD) observeResult(_ context.Context, result waitfor.ResourceResult) {
+	if result.Err != nil {
+		s.send("resource_failures", result.Resource, "1|c")
+		return
+	}
+
+	s.send("time_to_ready", result.Resource, fmt.Sprintf("%d|ms", result.Duration.Milliseconds()))
+}
+
+// send writes a metric, delivery errors are ignored as with any StatsD client
+func (s *StatsD) send(name, resource, value string) {
+	var scheme, host string
+
+	if u, err := url.Parse(resource); err == nil {
+		scheme, host = u.Scheme, u.Host
+	}
+
+	var line string
+
+	if s.tags {
+		line = fmt.Sprintf("%s.%s:%s|#scheme:%s,host:%s", s.prefix, name, value, tagReplacer.Replace(scheme), tagReplacer.Replace(host))
+	} else {
+		line = fmt.Sprintf("%s.%s.%s:%s", s.prefix, name, nameReplacer.Replace(scheme), value)
+	}
+
+	_, _ = s.conn.Write([]byte(line))
+}
+
+func env(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
@@ -0,0 +1,126 @@
+package statsd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyResource fails a given number of tests before it becomes available
+type flakyResource struct {
+	failures int
+}
+
+func (f *flakyResource) Test(_ context.Context) error {
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("not ready")
+	}
+
+	return nil
+}
+
+func newRunner() *waitfor.Runner {
+	return waitfor.New(waitfor.ResourceConfig{
+		Scheme: []string{"dns+srv"},
+		Factory: func(_ *url.URL) (waitfor.Resource, error) {
+			return &flakyResource{failures: 1}, nil
+		},
+	})
+}
+
+// receive collects metric lines sent to a fake server until it is quiet
+func receive(t *testing.T, pc net.PacketConn, count int) []string {
+	var lines []string
+
+	buf := make([]byte, 1024)
+
+	for len(lines) < count {
+		_ = pc.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := pc.ReadFrom(buf)
+
+		if !assert.NoError(t, err) {
+			break
+		}
+
+		lines = append(lines, string(buf[:n]))
+	}
+
+	sort.Strings(lines)
+
+	return lines
+}
+
+func TestStatsD(t *testing.T) {
+	cases := []struct {
+		setters []Option
+		lines   []string
+	}{
+		{
+			nil,
+			[]string{
+				"waitfor.attempt_failures.dns+srv:1|c",
+				"waitfor.attempts.dns+srv:1|c",
+				"waitfor.attempts.dns+srv:1|c",
+				"waitfor.time_to_ready.dns+srv:",
+			},
+		},
+		{
+			[]Option{WithTags(), WithPrefix("app.waitfor")},
+			[]string{
+				"app.waitfor.attempt_failures:1|c|#scheme:dns+srv,host:db.local:5432",
+				"app.waitfor.attempts:1|c|#scheme:dns+srv,host:db.local:5432",
+				"app.waitfor.attempts:1|c|#scheme:dns+srv,host:db.local:5432",
+				"app.waitfor.time_to_ready:",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+
+		assert.NoError(t, err)
+
+		s, err := New(pc.LocalAddr().String(), c.setters...)
+
+		assert.NoError(t, err)
+
+		err = newRunner().Test(context.Background(), []string{"dns+srv://db.local:5432"}, waitfor.WithInterval(0), s.Option())
+
+		assert.NoError(t, err)
+
+		lines := receive(t, pc, len(c.lines))
+
+		for i, line := range c.lines {
+			assert.True(t, strings.HasPrefix(lines[i], line), lines[i])
+		}
+
+		_ = s.Close()
+		_ = pc.Close()
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("DD_AGENT_HOST", "")
+	t.Setenv("STATSD_HOST", "")
+
+	_, err := FromEnv()
+
+	assert.ErrorIs(t, err, waitfor.ErrInvalidArgument)
+
+	t.Setenv("DD_AGENT_HOST", "127.0.0.1")
+
+	s, err := FromEnv()
+
+	assert.NoError(t, err)
+	assert.True(t, s.tags)
+	assert.Equal(t, "127.0.0.1:8125", s.conn.RemoteAddr().String())
+}
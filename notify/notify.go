@@ -0,0 +1,81 @@
+// Package notify sends chat messages when resources do not become available
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+// notifyTimeout bounds sending a message which is done even if the run is cancelled
+const notifyTimeout = 10 * time.Second
+
+type (
+	// Notifier delivers a message to people, e.g. a chat channel
+	Notifier interface {
+		Notify(ctx context.Context, message string) error
+	}
+
+	options struct {
+		service string
+		onError func(err error)
+	}
+
+	Option func(opts *options)
+)
+
+// Set a service name used in messages, it defaults to the current executable name
+func WithService(name string) Option {
+	return func(opts *options) {
+		opts.service = name
+	}
+}
+
+// Set a handler of delivery errors, they are ignored by default
+func WithErrorHandler(handler func(err error)) Option {
+	return func(opts *options) {
+		opts.onError = handler
+	}
+}
+
+// OnFailure returns a runner option sending a message when some of the resources are not available
+func OnFailure(n Notifier, setters ...Option) waitfor.Option {
+	opts := &options{service: filepath.Base(os.Args[0])}
+
+	for _, setter := range setters {
+		setter(opts)
+	}
+
+	return waitfor.WithCompletionHook(func(ctx context.Context, results []waitfor.ResourceResult, err error) {
+		if err == nil {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), notifyTimeout)
+		defer cancel()
+
+		if err := n.Notify(ctx, Message(opts.service, results)); err != nil && opts.onError != nil {
+			opts.onError(err)
+		}
+	})
+}
+
+// Message describes failed resources, e.g. "myapp waited 1m34s for postgres://db:5432/app and gave up: ..."
+func Message(service string, results []waitfor.ResourceResult) string {
+	var lines []string
+
+	for _, res := range results {
+		if res.Err == nil {
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("%s waited %s for %s and gave up: %s", service, res.Duration.Round(time.Second), waitfor.Redact(res.Resource), res.Err))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,73 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	downResource struct{}
+
+	recorder struct {
+		messages []string
+		err      error
+	}
+)
+
+func (downResource) Test(_ context.Context) error {
+	return errors.New("connection refused")
+}
+
+func (r *recorder) Notify(_ context.Context, message string) error {
+	r.messages = append(r.messages, message)
+	return r.err
+}
+
+func newRunner() *waitfor.Runner {
+	return waitfor.New(waitfor.ResourceConfig{
+		Scheme: []string{"down"},
+		Factory: func(_ *url.URL) (waitfor.Resource, error) {
+			return downResource{}, nil
+		},
+	})
+}
+
+func TestOnFailure(t *testing.T) {
+	r := new(recorder)
+
+	err := newRunner().Test(context.Background(), []string{"down://user:secret@db:5432/app"}, waitfor.WithInterval(0), waitfor.WithAttempts(1), OnFailure(r, WithService("api")))
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{"api waited 0s for down://user:xxxxx@db:5432/app and gave up: connection refused"}, r.messages)
+
+	err = newRunner().Test(context.Background(), nil, OnFailure(r))
+
+	assert.NoError(t, err)
+	assert.Len(t, r.messages, 1)
+}
+
+func TestOnFailure_Error(t *testing.T) {
+	r := &recorder{err: errors.New("unavailable")}
+
+	var notifyErr error
+
+	_ = newRunner().Test(context.Background(), []string{"down://db"}, waitfor.WithInterval(0), waitfor.WithAttempts(1), OnFailure(r, WithErrorHandler(func(err error) { notifyErr = err })))
+
+	assert.EqualError(t, notifyErr, "unavailable")
+}
+
+func TestMessage(t *testing.T) {
+	message := Message("worker", []waitfor.ResourceResult{
+		{Resource: "postgres://db:5432/app", Duration: 94 * time.Second, Err: errors.New("timeout")},
+		{Resource: "redis://cache:6379", Duration: time.Second},
+		{Resource: "file://./ready", Duration: 3 * time.Second, Err: errors.New("not found")},
+	})
+
+	assert.Equal(t, "worker waited 1m34s for postgres://db:5432/app and gave up: timeout\nworker waited 3s for file://./ready and gave up: not found", message)
+}
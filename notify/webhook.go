@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout of a webhook request
+const DefaultTimeout = 5 * time.Second
+
+// Webhook posts messages to a Slack or Mattermost compatible incoming webhook
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+var _ Notifier = (*Webhook)(nil)
+
+func NewWebhook(url string) *Webhook {
+	return &Webhook{
+		url:    url,
+		client: &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+func (w *Webhook) Notify(ctx context.Context, message string) error {
+	body, err := json.Marshal(map[string]string{"text": message})
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := w.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status: %s", res.Status)
+	}
+
+	return nil
+}
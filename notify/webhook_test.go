@@ -0,0 +1,31 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook_Notify(t *testing.T) {
+	var payload map[string]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/hooks/ok" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+	}))
+	defer srv.Close()
+
+	assert.NoError(t, NewWebhook(srv.URL+"/hooks/ok").Notify(context.Background(), "api gave up"))
+	assert.Equal(t, map[string]string{"text": "api gave up"}, payload)
+
+	assert.Error(t, NewWebhook(srv.URL+"/hooks/missing").Notify(context.Background(), "api gave up"))
+}
@@ -1,7 +1,12 @@
 package waitfor
 
 import (
+	"log/slog"
+	"os"
+	"slices"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 type (
@@ -9,6 +14,33 @@ type (
 		interval    time.Duration
 		maxInterval time.Duration
 		attempts    uint64
+
+		restartPolicy      RestartPolicy
+		restartInterval    time.Duration
+		restartMaxInterval time.Duration
+		maxRestarts        uint64
+		recheckOnRestart   bool
+
+		templates      bool
+		programTimeout time.Duration
+		programRetries uint64
+		pty            bool
+
+		killGracePeriod time.Duration
+		shutdownSignals []os.Signal
+
+		preExecHooks  []PreExecHook
+		postExecHooks []PostExecHook
+		attemptHooks  []AttemptHook
+		resultHooks   []ResultHook
+
+		startHooks      []StartHook
+		completionHooks []CompletionHook
+
+		logger *slog.Logger
+		tracer trace.Tracer
+
+		resourceOptions map[string][]Option
 	}
 
 	Option func(opts *Options)
@@ -20,6 +52,15 @@ func newOptions(setters []Option) *Options {
 		interval:    time.Duration(5) * time.Second,
 		maxInterval: time.Duration(60) * time.Second,
 		attempts:    5,
+
+		restartPolicy:      RestartAlways,
+		restartInterval:    time.Duration(1) * time.Second,
+		restartMaxInterval: time.Duration(60) * time.Second,
+
+		killGracePeriod: time.Duration(10) * time.Second,
+
+		logger: discardLogger,
+		tracer: noopTracer,
 	}
 
 	for _, setter := range setters {
@@ -29,6 +70,25 @@ func newOptions(setters []Option) *Options {
 	return opts
 }
 
+// forResource returns options with overrides of a given resource applied
+func (opts Options) forResource(resource string) Options {
+	setters := opts.resourceOptions[resource]
+
+	if len(setters) == 0 {
+		return opts
+	}
+
+	// appending hooks must not modify slices shared with other resources
+	opts.attemptHooks = slices.Clip(opts.attemptHooks)
+	opts.resultHooks = slices.Clip(opts.resultHooks)
+
+	for _, setter := range setters {
+		setter(&opts)
+	}
+
+	return opts
+}
+
 // Set a custom test interval
 func WithInterval(interval uint64) Option {
 	return func(opts *Options) {
@@ -43,9 +103,161 @@ func WithMaxInterval(interval uint64) Option {
 	}
 }
 
-// Set a custom attempts count
+// Set intervals between attempts with sub-second precision, e.g. in tests
+func WithIntervalDurations(interval, maxInterval time.Duration) Option {
+	return func(opts *Options) {
+		opts.interval = interval
+		opts.maxInterval = maxInterval
+	}
+}
+
+// Set a custom attempts count, 0 retries until the context is done
 func WithAttempts(attempts uint64) Option {
 	return func(opts *Options) {
 		opts.attempts = attempts
 	}
 }
+
+// Set a custom restart policy for supervised programs
+func WithRestartPolicy(policy RestartPolicy) Option {
+	return func(opts *Options) {
+		opts.restartPolicy = policy
+	}
+}
+
+// Set a custom interval between program restarts
+func WithRestartInterval(interval uint64) Option {
+	return func(opts *Options) {
+		opts.restartInterval = time.Duration(interval) * time.Second
+	}
+}
+
+// Set a custom maximum interval between program restarts
+func WithRestartMaxInterval(interval uint64) Option {
+	return func(opts *Options) {
+		opts.restartMaxInterval = time.Duration(interval) * time.Second
+	}
+}
+
+// Set a maximum number of program restarts, 0 means unlimited
+func WithMaxRestarts(restarts uint64) Option {
+	return func(opts *Options) {
+		opts.maxRestarts = restarts
+	}
+}
+
+// Re-test resources before every program restart
+func WithRecheckOnRestart() Option {
+	return func(opts *Options) {
+		opts.recheckOnRestart = true
+	}
+}
+
+// Expand templates in program arguments and environment variables using tested resources
+func WithTemplates() Option {
+	return func(opts *Options) {
+		opts.templates = true
+	}
+}
+
+// Set a custom program timeout, independent of resource availability tests
+func WithProgramTimeout(timeout uint64) Option {
+	return func(opts *Options) {
+		opts.programTimeout = time.Duration(timeout) * time.Second
+	}
+}
+
+// Add a hook called after resources are available and before a program starts
+func WithPreExecHook(hook PreExecHook) Option {
+	return func(opts *Options) {
+		opts.preExecHooks = append(opts.preExecHooks, hook)
+	}
+}
+
+// Add a hook called after a program exits
+func WithPostExecHook(hook PostExecHook) Option {
+	return func(opts *Options) {
+		opts.postExecHooks = append(opts.postExecHooks, hook)
+	}
+}
+
+// Add a hook called after every resource test attempt
+func WithAttemptHook(hook AttemptHook) Option {
+	return func(opts *Options) {
+		opts.attemptHooks = append(opts.attemptHooks, hook)
+	}
+}
+
+// Add a hook called with the final outcome of every resource test
+func WithResultHook(hook ResultHook) Option {
+	return func(opts *Options) {
+		opts.resultHooks = append(opts.resultHooks, hook)
+	}
+}
+
+// Add a hook called before resources are tested
+func WithStartHook(hook StartHook) Option {
+	return func(opts *Options) {
+		opts.startHooks = append(opts.startHooks, hook)
+	}
+}
+
+// Add a hook called once all resources are tested
+func WithCompletionHook(hook CompletionHook) Option {
+	return func(opts *Options) {
+		opts.completionHooks = append(opts.completionHooks, hook)
+	}
+}
+
+// Set a number of program retries when it exits with a non-zero status
+func WithProgramRetries(retries uint64) Option {
+	return func(opts *Options) {
+		opts.programRetries = retries
+	}
+}
+
+// Run a program attached to a pseudo-terminal, stdout and stderr are merged
+func WithPTY() Option {
+	return func(opts *Options) {
+		opts.pty = true
+	}
+}
+
+// Set a custom period between asking a program to terminate and killing it
+func WithKillGracePeriod(period uint64) Option {
+	return func(opts *Options) {
+		opts.killGracePeriod = time.Duration(period) * time.Second
+	}
+}
+
+// Terminate a program gracefully when one of given signals arrives
+func WithShutdownSignals(signals ...os.Signal) Option {
+	return func(opts *Options) {
+		opts.shutdownSignals = append(opts.shutdownSignals, signals...)
+	}
+}
+
+// Set a logger for resource resolution, test attempts, retry delays and outcomes
+func WithLogger(logger *slog.Logger) Option {
+	return func(opts *Options) {
+		opts.logger = logger
+	}
+}
+
+// Set an OpenTelemetry tracer provider for spans of runs, resources and test attempts
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(opts *Options) {
+		opts.tracer = provider.Tracer(tracerName)
+	}
+}
+
+// Override options of a single resource, e.g. its attempts and intervals
+func WithResourceOptions(resource string, setters ...Option) Option {
+	return func(opts *Options) {
+		if opts.resourceOptions == nil {
+			opts.resourceOptions = make(map[string][]Option)
+		}
+
+		opts.resourceOptions[resource] = append(opts.resourceOptions[resource], setters...)
+	}
+}
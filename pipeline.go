@@ -0,0 +1,143 @@
+package waitfor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	// FailurePolicyAbort stops the pipeline when any program of a stage fails
+	FailurePolicyAbort FailurePolicy = iota
+	// FailurePolicyContinue ignores failures of a stage and proceeds to the next one
+	FailurePolicyContinue
+)
+
+type (
+	FailurePolicy int
+
+	// Stage is a set of programs executed in parallel
+	Stage struct {
+		Programs  []Program
+		OnFailure FailurePolicy
+	}
+
+	// Pipeline is a sequence of stages executed one by one once resources are available
+	Pipeline struct {
+		Resources []string
+		Stages    []Stage
+	}
+
+	PipelineResult struct {
+		Program Program
+		Output  []byte
+		Err     error
+	}
+)
+
+// RunPipeline runs resource availability tests and executes pipeline stages sequentially.
+// Results of all executed programs are returned even if the pipeline is aborted.
+func (r *Runner) RunPipeline(ctx context.Context, pipeline Pipeline, setters ...Option) ([]PipelineResult, error) {
+	err := r.Test(ctx, pipeline.Resources, setters...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]PipelineResult, 0, len(pipeline.Stages))
+
+	for i, stage := range pipeline.Stages {
+		stageResults := r.runStage(ctx, stage, setters)
+		results = append(results, stageResults...)
+
+		if stage.OnFailure == FailurePolicyContinue {
+			continue
+		}
+
+		for _, res := range stageResults {
+			if res.Err != nil {
+				return results, fmt.Errorf("stage %d: %s: %w", i, res.Program.Executable, res.Err)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// runStage tests resources of all programs of a stage once and executes the programs in parallel,
+// pre-exec and post-exec hooks are called for every program with the results of the stage test
+func (r *Runner) runStage(ctx context.Context, stage Stage, setters []Option) []PipelineResult {
+	opts := newOptions(setters)
+	results := make([]PipelineResult, len(stage.Programs))
+
+	var tested []ResourceResult
+
+	if resources := stageResources(stage); len(resources) > 0 {
+		var err error
+
+		tested, err = r.testAll(ctx, resources, opts)
+
+		if err != nil {
+			for i, program := range stage.Programs {
+				results[i] = PipelineResult{Program: program, Err: err}
+			}
+
+			return results
+		}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(stage.Programs))
+
+	for i, program := range stage.Programs {
+		i, program := i, program
+
+		go func() {
+			defer wg.Done()
+
+			out, err := r.runStageProgram(ctx, program, tested, setters)
+
+			results[i] = PipelineResult{
+				Program: program,
+				Output:  out,
+				Err:     err,
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// runStageProgram executes a program of a stage whose resources are already available
+func (r *Runner) runStageProgram(ctx context.Context, program Program, tested []ResourceResult, setters []Option) ([]byte, error) {
+	opts := newOptions(setters)
+
+	if err := r.beforeProgram(ctx, program, tested, opts); err != nil {
+		return nil, err
+	}
+
+	out, err := r.retryProgram(ctx, program, setters)
+	r.afterProgram(ctx, program, tested, err, opts)
+
+	return out, err
+}
+
+// stageResources returns resources of all programs of a stage, each of them once
+func stageResources(stage Stage) []string {
+	var resources []string
+
+	seen := make(map[string]bool)
+
+	for _, program := range stage.Programs {
+		for _, resource := range program.Resources {
+			if !seen[resource] {
+				seen[resource] = true
+				resources = append(resources, resource)
+			}
+		}
+	}
+
+	return resources
+}
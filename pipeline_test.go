@@ -0,0 +1,89 @@
+package waitfor
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunner_RunPipeline(t *testing.T) {
+	r := New()
+
+	results, err := r.RunPipeline(context.Background(), Pipeline{
+		Stages: []Stage{
+			{
+				Programs:  []Program{{Executable: "false"}},
+				OnFailure: FailurePolicyContinue,
+			},
+			{
+				Programs: []Program{
+					{Executable: "echo", Args: []string{"a"}},
+					{Executable: "echo", Args: []string{"b"}},
+				},
+			},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Error(t, results[0].Err)
+	assert.Equal(t, "a\n", string(results[1].Output))
+	assert.Equal(t, "b\n", string(results[2].Output))
+}
+
+func TestRunner_RunPipeline_Abort(t *testing.T) {
+	r := New()
+
+	results, err := r.RunPipeline(context.Background(), Pipeline{
+		Stages: []Stage{
+			{Programs: []Program{{Executable: "false"}}},
+			{Programs: []Program{{Executable: "true"}}},
+		},
+	})
+
+	assert.Error(t, err)
+	assert.Len(t, results, 1)
+}
+
+func TestRunner_RunPipeline_Hooks(t *testing.T) {
+	r := New(useFlakyResource(&flakyResource{}))
+
+	var starts, completions, preExecs, postExecs atomic.Int32
+	var resources atomic.Int32
+
+	_, err := r.RunPipeline(context.Background(), Pipeline{
+		Stages: []Stage{
+			{
+				Programs: []Program{
+					{Executable: "true", Resources: []string{"flaky://db"}},
+					{Executable: "true", Resources: []string{"flaky://db", "flaky://cache"}},
+					{Executable: "true"},
+				},
+			},
+		},
+	},
+		WithInterval(0),
+		WithStartHook(func(_ context.Context, _ []string) { starts.Add(1) }),
+		WithCompletionHook(func(_ context.Context, results []ResourceResult, _ error) {
+			completions.Add(1)
+			resources.Add(int32(len(results)))
+		}),
+		WithPreExecHook(func(_ context.Context, _ Program, results []ResourceResult) error {
+			assert.Len(t, results, 2)
+			preExecs.Add(1)
+
+			return nil
+		}),
+		WithPostExecHook(func(_ context.Context, _ Program, _ []ResourceResult, _ error) { postExecs.Add(1) }),
+	)
+
+	assert.NoError(t, err)
+	// the pipeline test of no resources and a single stage test
+	assert.Equal(t, int32(2), starts.Load())
+	assert.Equal(t, int32(2), completions.Load())
+	assert.Equal(t, int32(2), resources.Load())
+	assert.Equal(t, int32(3), preExecs.Load())
+	assert.Equal(t, int32(3), postExecs.Load())
+}
@@ -0,0 +1,221 @@
+package waitfor
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+)
+
+type (
+	// Process is a handle of a program started by Runner.Start
+	Process struct {
+		ctx    context.Context
+		cancel context.CancelFunc
+		opts   *Options
+		cmd    *exec.Cmd
+		stdout io.Reader
+		stderr io.Reader
+		errBuf *cappedBuffer
+		after  func(err error)
+		// exited is closed once the program exits and its output is drained, err is its wait error
+		exited chan struct{}
+		err    error
+		// wait runs post-exec hooks once, result is returned by every call to Wait
+		wait   sync.Once
+		result error
+	}
+
+	// spool keeps output of a program until it is read, so the program never blocks on a full pipe,
+	// e.g. while its post-start resources are tested
+	spool struct {
+		mu     sync.Mutex
+		cond   *sync.Cond
+		buf    bytes.Buffer
+		closed bool
+	}
+)
+
+// startProgram starts a given program without waiting for its completion
+func (r *Runner) startProgram(ctx context.Context, program Program, setters []Option) (*Process, error) {
+	opts := newOptions(setters)
+	program, err := prepareProgram(program, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := programContext(ctx, opts)
+	cmd, err := newCommand(ctx, program, opts)
+
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	p := &Process{
+		ctx:    ctx,
+		cancel: cancel,
+		opts:   opts,
+		cmd:    cmd,
+		errBuf: &cappedBuffer{limit: maxCapturedStderr},
+		exited: make(chan struct{}),
+	}
+
+	if err := p.start(program); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if err := r.verifyProgram(ctx, program, setters, p.kill); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// start attaches output streams, starts the process and waits for its exit in the background
+func (p *Process) start(program Program) error {
+	cmd := p.cmd
+
+	var spools []*spool
+
+	if program.Stdout != nil {
+		cmd.Stdout = program.Stdout
+	} else {
+		stdout := newSpool()
+		spools = append(spools, stdout)
+
+		cmd.Stdout, p.stdout = stdout, stdout
+	}
+
+	if program.Stderr != nil {
+		cmd.Stderr = io.MultiWriter(program.Stderr, p.errBuf)
+	} else if !p.opts.pty {
+		stderr := newSpool()
+		spools = append(spools, stderr)
+
+		cmd.Stderr, p.stderr = io.MultiWriter(stderr, p.errBuf), stderr
+	}
+
+	var tty *ttySession
+	var err error
+
+	// the terminal merges stdout and stderr into a single stream
+	if p.opts.pty {
+		tty, err = startTTY(cmd)
+	} else {
+		err = cmd.Start()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer close(p.exited)
+
+		p.err = cmd.Wait()
+
+		if tty != nil {
+			_ = tty.Close()
+		}
+
+		for _, s := range spools {
+			s.Close()
+		}
+	}()
+
+	return nil
+}
+
+// kill stops the process and waits for its exit
+func (p *Process) kill() {
+	_ = p.cmd.Process.Kill()
+	<-p.exited
+}
+
+// Pid returns the process id
+func (p *Process) Pid() int {
+	return p.cmd.Process.Pid
+}
+
+// Signal sends a signal to the process
+func (p *Process) Signal(sig os.Signal) error {
+	return p.cmd.Process.Signal(sig)
+}
+
+// Stdout returns the process output stream or nil if Program.Stdout is set.
+// The output is kept in memory until it is read, it may also be read after Wait.
+// With WithPTY the stream merges stdout and stderr of the terminal.
+func (p *Process) Stdout() io.Reader {
+	return p.stdout
+}
+
+// Stderr returns the process error stream or nil if Program.Stderr or WithPTY is set.
+// The output is kept in memory until it is read, it may also be read after Wait.
+func (p *Process) Stderr() io.Reader {
+	return p.stderr
+}
+
+// Wait waits for the process to exit, post-exec hooks run once and later calls return the same error
+func (p *Process) Wait() error {
+	<-p.exited
+
+	p.wait.Do(func() {
+		defer p.cancel()
+
+		p.result = programError(p.ctx, p.opts, newExitError(p.err, p.errBuf))
+
+		if p.after != nil {
+			p.after(p.result)
+		}
+	})
+
+	return p.result
+}
+
+func newSpool() *spool {
+	s := new(spool)
+	s.cond = sync.NewCond(&s.mu)
+
+	return s
+}
+
+func (s *spool) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.buf.Write(p)
+	s.cond.Broadcast()
+
+	return len(p), nil
+}
+
+// Read blocks until output is available or the program exits
+func (s *spool) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for s.buf.Len() == 0 && !s.closed {
+		s.cond.Wait()
+	}
+
+	if s.buf.Len() == 0 {
+		return 0, io.EOF
+	}
+
+	return s.buf.Read(p)
+}
+
+// Close ends the stream once the remaining output is read
+func (s *spool) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	s.cond.Broadcast()
+}
@@ -0,0 +1,103 @@
+package waitfor
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunner_Start(t *testing.T) {
+	r := New()
+
+	p, err := r.Start(context.Background(), Program{
+		Executable: "sh",
+		Args:       []string{"-c", "echo hello; echo failure >&2; exit 2"},
+	})
+
+	assert.NoError(t, err)
+	assert.Greater(t, p.Pid(), 0)
+
+	stdout, _ := io.ReadAll(p.Stdout())
+	stderr, _ := io.ReadAll(p.Stderr())
+
+	assert.Equal(t, "hello\n", string(stdout))
+	assert.Equal(t, "failure\n", string(stderr))
+
+	var exitErr *ExitError
+
+	assert.ErrorAs(t, p.Wait(), &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+	assert.Equal(t, "failure\n", string(exitErr.Stderr))
+}
+
+func TestProcess_Signal(t *testing.T) {
+	r := New()
+
+	p, err := r.Start(context.Background(), Program{
+		Executable: "sleep",
+		Args:       []string{"10"},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, p.Signal(syscall.SIGTERM))
+	assert.Error(t, p.Wait())
+}
+
+func TestRunner_Start_PostResources(t *testing.T) {
+	ready := filepath.Join(t.TempDir(), "ready")
+	r := New(useFileResource())
+
+	// the output exceeds a pipe buffer before the post-start resource appears
+	p, err := r.Start(context.Background(), Program{
+		Executable:    "sh",
+		Args:          []string{"-c", "head -c 1048576 /dev/zero; touch " + ready},
+		PostResources: []string{"file://" + ready},
+	}, WithIntervalDurations(10*time.Millisecond, 10*time.Millisecond), WithAttempts(100))
+
+	assert.NoError(t, err)
+
+	stdout, _ := io.ReadAll(p.Stdout())
+
+	assert.Len(t, stdout, 1048576)
+	assert.NoError(t, p.Wait())
+}
+
+func TestRunner_Start_PTY(t *testing.T) {
+	r := New()
+
+	p, err := r.Start(context.Background(), Program{
+		Executable: "sh",
+		Args:       []string{"-c", "[ -t 1 ] && echo tty"},
+	}, WithPTY())
+
+	assert.NoError(t, err)
+	assert.Nil(t, p.Stderr())
+	assert.NoError(t, p.Wait())
+
+	stdout, _ := io.ReadAll(p.Stdout())
+
+	assert.Equal(t, "tty\r\n", string(stdout))
+}
+
+func TestProcess_Wait_Twice(t *testing.T) {
+	r := New()
+
+	var hooks int
+
+	p, err := r.Start(context.Background(), Program{Executable: "false"}, WithPostExecHook(func(_ context.Context, _ Program, _ []ResourceResult, _ error) {
+		hooks++
+	}))
+
+	assert.NoError(t, err)
+
+	first := p.Wait()
+
+	assert.Error(t, first)
+	assert.Equal(t, first, p.Wait())
+	assert.Equal(t, 1, hooks)
+}
@@ -0,0 +1,290 @@
+package waitfor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+
+	"github.com/cenkalti/backoff"
+)
+
+// maxCapturedStderr limits the amount of stderr kept for ExitError
+const maxCapturedStderr = 64 * 1024
+
+type (
+	Program struct {
+		Executable string
+		Args       []string
+		Resources  []string
+		// Env is a list of additional environment variables in the form "key=value"
+		Env []string
+		// PostResources are tested after the program starts
+		PostResources []string
+		Stdin         io.Reader
+		Stdout        io.Writer
+		Stderr        io.Writer
+		// Credential is a user and groups the program runs as (Unix only)
+		Credential *Credential
+	}
+
+	Credential struct {
+		UID    uint32
+		GID    uint32
+		Groups []uint32
+	}
+
+	// cappedBuffer keeps the first bytes written to it and silently drops the rest
+	cappedBuffer struct {
+		buf   bytes.Buffer
+		limit int
+	}
+
+	// lockedBuffer is a buffer safe for concurrent writes from stdout and stderr
+	lockedBuffer struct {
+		mu  sync.Mutex
+		buf bytes.Buffer
+	}
+)
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if left := b.limit - b.buf.Len(); left > 0 {
+		if len(p) > left {
+			b.buf.Write(p[:left])
+		} else {
+			b.buf.Write(p)
+		}
+	}
+
+	return len(p), nil
+}
+
+// runProgram executes a given program and waits for its completion
+func (r *Runner) runProgram(ctx context.Context, program Program, setters []Option) ([]byte, error) {
+	opts := newOptions(setters)
+	program, err := prepareProgram(program, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := programContext(ctx, opts)
+	defer cancel()
+
+	cmd, err := newCommand(ctx, program, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stderr := &cappedBuffer{limit: maxCapturedStderr}
+
+	var combined *lockedBuffer
+
+	// without custom writers the output is buffered and returned to the caller
+	if program.Stdout == nil && program.Stderr == nil {
+		combined = new(lockedBuffer)
+
+		cmd.Stdout = combined
+		cmd.Stderr = io.MultiWriter(combined, stderr)
+	} else {
+		cmd.Stdout = program.Stdout
+		cmd.Stderr = stderr
+
+		if program.Stderr != nil {
+			cmd.Stderr = io.MultiWriter(program.Stderr, stderr)
+		}
+	}
+
+	output := func() []byte {
+		if combined == nil {
+			return nil
+		}
+
+		return combined.buf.Bytes()
+	}
+
+	var tty *ttySession
+
+	// the terminal merges stdout and stderr into a single stream
+	if opts.pty {
+		tty, err = startTTY(cmd)
+	} else {
+		err = cmd.Start()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.verifyProgram(ctx, program, setters, func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	if err == nil {
+		err = programError(ctx, opts, newExitError(cmd.Wait(), stderr))
+	}
+
+	if tty != nil {
+		_ = tty.Close()
+	}
+
+	return output(), err
+}
+
+// programContext bounds the program runtime by the timeout and shutdown signals if they are set
+func programContext(ctx context.Context, opts *Options) (context.Context, context.CancelFunc) {
+	stop := func() {}
+
+	if len(opts.shutdownSignals) > 0 {
+		ctx, stop = signal.NotifyContext(ctx, opts.shutdownSignals...)
+	}
+
+	var cancel context.CancelFunc
+
+	if opts.programTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, opts.programTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// programError reports the program timeout instead of a kill signal
+func programError(ctx context.Context, opts *Options, err error) error {
+	if err != nil && opts.programTimeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("program timed out after %s: %w", opts.programTimeout, err)
+	}
+
+	return err
+}
+
+// prepareProgram applies run options to a program before its execution
+func prepareProgram(program Program, opts *Options) (Program, error) {
+	if !opts.templates {
+		return program, nil
+	}
+
+	return expandProgram(program)
+}
+
+// newCommand creates a command for a given program
+func newCommand(ctx context.Context, program Program, opts *Options) (*exec.Cmd, error) {
+	cmd := exec.CommandContext(ctx, program.Executable, program.Args...)
+	cmd.Stdin = program.Stdin
+
+	// on cancellation the program is asked to terminate and killed after the grace period
+	cmd.Cancel = func() error {
+		return terminate(cmd.Process)
+	}
+	cmd.WaitDelay = opts.killGracePeriod
+
+	if len(program.Env) > 0 {
+		cmd.Env = append(os.Environ(), program.Env...)
+	}
+
+	if program.Credential != nil {
+		if err := setCredential(cmd, program.Credential); err != nil {
+			return nil, err
+		}
+	}
+
+	return cmd, nil
+}
+
+// retryProgram executes a given program and retries it with backoff when it exits with a non-zero status
+func (r *Runner) retryProgram(ctx context.Context, program Program, setters []Option) ([]byte, error) {
+	opts := newOptions(setters)
+
+	if opts.programRetries == 0 {
+		return r.runProgram(ctx, program, setters)
+	}
+
+	var out []byte
+
+	err := backoff.Retry(func() error {
+		var err error
+		var exitErr *ExitError
+
+		out, err = r.runProgram(ctx, program, setters)
+
+		if err != nil && !errors.As(err, &exitErr) {
+			return backoff.Permanent(err)
+		}
+
+		return err
+	}, backoff.WithContext(backoff.WithMaxRetries(newBackOff(*opts), opts.programRetries), ctx))
+
+	return out, err
+}
+
+// verifyProgram tests post-start resources of a running program and stops it by kill if they are not available
+func (r *Runner) verifyProgram(ctx context.Context, program Program, setters []Option, kill func()) error {
+	if len(program.PostResources) == 0 {
+		return nil
+	}
+
+	err := r.Test(ctx, program.PostResources, setters...)
+
+	if err != nil {
+		kill()
+
+		return fmt.Errorf("post-start verification: %w", err)
+	}
+
+	return nil
+}
+
+// newExitError converts a non-zero exit status into ExitError
+func newExitError(err error, stderr *cappedBuffer) error {
+	var exitErr *exec.ExitError
+
+	if !errors.As(err, &exitErr) {
+		return err
+	}
+
+	return &ExitError{
+		Code:   exitErr.ExitCode(),
+		Stderr: stderr.buf.Bytes(),
+		err:    err,
+	}
+}
+
+// ParseProgram splits "resource... -- executable args..." style arguments into a program.
+// Arguments without the separator are treated as resources only.
+func ParseProgram(args []string) (Program, error) {
+	for i, arg := range args {
+		if arg != "--" {
+			continue
+		}
+
+		if i == len(args)-1 {
+			return Program{}, fmt.Errorf("%q: missing executable after separator: %w", "args", ErrInvalidArgument)
+		}
+
+		return Program{
+			Resources:  args[:i:i],
+			Executable: args[i+1],
+			Args:       args[i+2:],
+		}, nil
+	}
+
+	return Program{Resources: args}, nil
+}
@@ -0,0 +1,30 @@
+package waitfor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProgram(t *testing.T) {
+	program, err := ParseProgram([]string{"tcp://db:5432", "http://api/health", "--", "./server", "--flag", "--", "x"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tcp://db:5432", "http://api/health"}, program.Resources)
+	assert.Equal(t, "./server", program.Executable)
+	assert.Equal(t, []string{"--flag", "--", "x"}, program.Args)
+}
+
+func TestParseProgram_ResourcesOnly(t *testing.T) {
+	program, err := ParseProgram([]string{"tcp://db:5432"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tcp://db:5432"}, program.Resources)
+	assert.Empty(t, program.Executable)
+}
+
+func TestParseProgram_MissingExecutable(t *testing.T) {
+	_, err := ParseProgram([]string{"tcp://db:5432", "--"})
+
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
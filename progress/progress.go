@@ -0,0 +1,211 @@
+// Package progress renders a live table of resource tests in a terminal
+package progress
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+// DefaultRefreshInterval is the time between redraws of the table
+const DefaultRefreshInterval = 100 * time.Millisecond
+
+// frames of the spinner shown next to resources which are being tested
+var frames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+type (
+	// row is a state of a single resource
+	row struct {
+		resource string
+		attempt  int
+		next     time.Time
+		err      error
+		done     bool
+		duration time.Duration
+	}
+
+	// Renderer redraws a table with a row per resource showing the attempt count and the next retry countdown.
+	// It renders a single run at a time.
+	Renderer struct {
+		w        io.Writer
+		interval time.Duration
+
+		mu    sync.Mutex
+		rows  []*row
+		index map[string]*row
+		drawn int
+		frame int
+		stop  chan struct{}
+		wg    sync.WaitGroup
+	}
+)
+
+// New creates a renderer writing to a terminal
+func New(w io.Writer) *Renderer {
+	return &Renderer{
+		w:        w,
+		interval: DefaultRefreshInterval,
+	}
+}
+
+// Auto returns a runner option rendering progress to a given file only if it is a terminal
+func Auto(f *os.File) waitfor.Option {
+	if !IsTerminal(f) {
+		return func(_ *waitfor.Options) {}
+	}
+
+	return New(f).Option()
+}
+
+// IsTerminal reports whether a file is a character device, e.g. an interactive terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Option returns a runner option rendering progress of every test
+func (r *Renderer) Option() waitfor.Option {
+	return func(opts *waitfor.Options) {
+		waitfor.WithStartHook(r.start)(opts)
+		waitfor.WithAttemptHook(r.attempt)(opts)
+		waitfor.WithResultHook(r.result)(opts)
+		waitfor.WithCompletionHook(r.complete)(opts)
+	}
+}
+
+func (r *Renderer) start(_ context.Context, resources []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rows = make([]*row, 0, len(resources))
+	r.index = make(map[string]*row, len(resources))
+	r.drawn = 0
+
+	for _, resource := range resources {
+		if _, found := r.index[resource]; found {
+			continue
+		}
+
+		rw := &row{resource: resource}
+
+		r.rows = append(r.rows, rw)
+		r.index[resource] = rw
+	}
+
+	r.draw()
+
+	r.stop = make(chan struct{})
+	r.wg.Add(1)
+
+	go r.loop(r.stop)
+}
+
+func (r *Renderer) attempt(_ context.Context, attempt waitfor.Attempt) {
+	r.update(attempt.Resource, func(rw *row) {
+		rw.attempt = attempt.Number
+		rw.err = attempt.Err
+		rw.next = time.Now().Add(attempt.Delay)
+	})
+}
+
+func (r *Renderer) result(_ context.Context, result waitfor.ResourceResult) {
+	r.update(result.Resource, func(rw *row) {
+		rw.done = true
+		rw.err = result.Err
+		rw.attempt = result.Attempts
+		rw.duration = result.Duration
+	})
+}
+
+func (r *Renderer) complete(_ context.Context, _ []waitfor.ResourceResult, _ error) {
+	close(r.stop)
+	r.wg.Wait()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.draw()
+}
+
+func (r *Renderer) update(resource string, fn func(rw *row)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if rw, found := r.index[resource]; found {
+		fn(rw)
+	}
+}
+
+func (r *Renderer) loop(stop <-chan struct{}) {
+	defer r.wg.Done()
+
+	t := time.NewTicker(r.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+		}
+
+		r.mu.Lock()
+		r.frame++
+		r.draw()
+		r.mu.Unlock()
+	}
+}
+
+// draw moves the cursor to the beginning of the table and rewrites it, the caller holds the lock
+func (r *Renderer) draw() {
+	var b strings.Builder
+
+	if r.drawn > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA", r.drawn)
+	}
+
+	for _, rw := range r.rows {
+		// multiline errors would break moving the cursor up
+		fmt.Fprintf(&b, "\x1b[2K%s\n", strings.ReplaceAll(r.describe(rw), "\n", " "))
+	}
+
+	r.drawn = len(r.rows)
+
+	_, _ = io.WriteString(r.w, b.String())
+}
+
+func (r *Renderer) describe(rw *row) string {
+	resource := waitfor.Redact(rw.resource)
+
+	switch {
+	case rw.done && rw.err == nil:
+		return fmt.Sprintf("✓ %s ready after %s (%s)", resource, attempts(rw.attempt), rw.duration.Round(time.Millisecond))
+	case rw.done:
+		return fmt.Sprintf("✗ %s failed after %s: %s", resource, attempts(rw.attempt), rw.err)
+	case rw.attempt == 0:
+		return fmt.Sprintf("%s %s testing", frames[r.frame%len(frames)], resource)
+	}
+
+	wait := time.Until(rw.next).Round(time.Second)
+
+	if wait < 0 {
+		wait = 0
+	}
+
+	return fmt.Sprintf("%s %s retry in %s after %s: %s", frames[r.frame%len(frames)], resource, wait, attempts(rw.attempt), rw.err)
+}
+
+func attempts(n int) string {
+	if n == 1 {
+		return "1 attempt"
+	}
+
+	return fmt.Sprintf("%d attempts", n)
+}
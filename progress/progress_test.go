@@ -0,0 +1,82 @@
+package progress
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyResource fails a given number of tests before it becomes available
+type flakyResource struct {
+	failures int
+}
+
+func (f *flakyResource) Test(_ context.Context) error {
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("connection\nrefused")
+	}
+
+	return nil
+}
+
+func newRunner() *waitfor.Runner {
+	return waitfor.New(waitfor.ResourceConfig{
+		Scheme: []string{"flaky"},
+		Factory: func(u *url.URL) (waitfor.Resource, error) {
+			if u.Host == "down" {
+				return &flakyResource{failures: 100}, nil
+			}
+
+			return &flakyResource{failures: 1}, nil
+		},
+	})
+}
+
+func TestRenderer(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := newRunner().Test(
+		context.Background(),
+		[]string{"flaky://user:secret@up", "flaky://down"},
+		waitfor.WithInterval(0),
+		waitfor.WithAttempts(1),
+		New(&buf).Option(),
+	)
+
+	assert.Error(t, err)
+
+	out := buf.String()
+	frames := strings.Split(out, "\x1b[2A")
+	last := frames[len(frames)-1]
+
+	assert.NotContains(t, out, "secret")
+	assert.Contains(t, frames[0], "flaky://user:xxxxx@up testing")
+	assert.Contains(t, frames[0], "flaky://down testing")
+	assert.Contains(t, last, "✓ flaky://user:xxxxx@up ready after 2 attempts")
+	assert.Contains(t, last, "✗ flaky://down failed after 2 attempts: connection refused")
+}
+
+func TestAuto(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "out"))
+
+	assert.NoError(t, err)
+
+	defer f.Close()
+
+	assert.False(t, IsTerminal(f))
+	assert.NoError(t, newRunner().Test(context.Background(), []string{"flaky://up"}, waitfor.WithInterval(0), Auto(f)))
+
+	info, err := f.Stat()
+
+	assert.NoError(t, err)
+	assert.Zero(t, info.Size())
+}
@@ -0,0 +1,60 @@
+package waitfor
+
+import (
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/creack/pty"
+)
+
+// ttySession copies I/O between a pseudo-terminal and program streams
+type ttySession struct {
+	ptmx *os.File
+	done chan struct{}
+}
+
+// startTTY starts a command attached to a pseudo-terminal.
+// The command output written to the terminal is copied to its Stdout writer.
+func startTTY(cmd *exec.Cmd) (*ttySession, error) {
+	stdin, stdout := cmd.Stdin, cmd.Stdout
+
+	if stdout == nil {
+		stdout = io.Discard
+	}
+
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = nil, nil, nil
+
+	ptmx, err := pty.Start(cmd)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s := &ttySession{
+		ptmx: ptmx,
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(s.done)
+
+		// reading fails once the program exits and the terminal is drained
+		_, _ = io.Copy(stdout, ptmx)
+	}()
+
+	if stdin != nil {
+		go func() {
+			_, _ = io.Copy(ptmx, stdin)
+		}()
+	}
+
+	return s, nil
+}
+
+// Close waits for the remaining output and closes the terminal, it must be called after the command exits
+func (s *ttySession) Close() error {
+	<-s.done
+
+	return s.ptmx.Close()
+}
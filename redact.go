@@ -0,0 +1,88 @@
+package waitfor
+
+import (
+	"net/url"
+	"strings"
+)
+
+// redacted replaces hidden values
+const redacted = "xxxxx"
+
+// sensitiveParams are options of resource locations which carry credentials,
+// e.g. the bearer token or the headers of an HTTP check, a "dsn" option is redacted by redactDSN
+var sensitiveParams = map[string]bool{
+	"bearer":   true,
+	"header":   true,
+	"token":    true,
+	"password": true,
+}
+
+// Redact hides credentials in a resource location, it is used wherever a location
+// leaves the runner: errors, logs, events, metrics and reports.
+// A password and the values of sensitive options are replaced, a location which
+// cannot be parsed is reduced to its scheme.
+func Redact(resource string) string {
+	u, err := url.Parse(resource)
+
+	if err != nil {
+		if scheme, _, ok := strings.Cut(resource, "://"); ok {
+			return scheme + "://" + redacted
+		}
+
+		return redacted
+	}
+
+	u.RawQuery = redactQuery(u.RawQuery)
+
+	return u.Redacted()
+}
+
+// redactQuery replaces values of sensitive options keeping the order and encoding of the others
+func redactQuery(query string) string {
+	if query == "" {
+		return query
+	}
+
+	params := strings.Split(query, "&")
+
+	for i, param := range params {
+		key, value, ok := strings.Cut(param, "=")
+
+		if !ok {
+			continue
+		}
+
+		name, err := url.QueryUnescape(key)
+
+		if err != nil {
+			continue
+		}
+
+		switch name = strings.ToLower(name); {
+		case sensitiveParams[name]:
+			params[i] = key + "=" + redacted
+		case name == "dsn":
+			params[i] = key + "=" + url.QueryEscape(redactDSN(value))
+		}
+	}
+
+	return strings.Join(params, "&")
+}
+
+// redactDSN hides the password of a data source name given as a url,
+// other forms, e.g. "user:pass@tcp(host)/db" or "host=db password=pass", are hidden entirely
+func redactDSN(value string) string {
+	dsn, err := url.QueryUnescape(value)
+
+	if err != nil {
+		return redacted
+	}
+
+	u, err := url.Parse(dsn)
+
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return redacted
+	}
+
+	return Redact(dsn)
+}
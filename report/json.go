@@ -0,0 +1,51 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type (
+	jsonReport struct {
+		OK        bool           `json:"ok"`
+		Duration  int64          `json:"duration_ms"`
+		Resources []jsonResource `json:"resources"`
+	}
+
+	jsonResource struct {
+		Resource string `json:"resource"`
+		OK       bool   `json:"ok"`
+		Attempts int    `json:"attempts"`
+		Duration int64  `json:"duration_ms"`
+		Error    string `json:"error,omitempty"`
+	}
+)
+
+func writeJSON(w io.Writer, results []result) error {
+	report := jsonReport{
+		OK:        true,
+		Duration:  elapsed(results).Milliseconds(),
+		Resources: make([]jsonResource, 0, len(results)),
+	}
+
+	for _, r := range results {
+		res := jsonResource{
+			Resource: r.resource,
+			OK:       r.err == nil,
+			Attempts: r.attempts,
+			Duration: r.duration.Milliseconds(),
+		}
+
+		if r.err != nil {
+			report.OK = false
+			res.Error = r.err.Error()
+		}
+
+		report.Resources = append(report.Resources, res)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(report)
+}
@@ -0,0 +1,80 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+type (
+	junitSuites struct {
+		XMLName xml.Name     `xml:"testsuites"`
+		Suites  []junitSuite `xml:"testsuite"`
+	}
+
+	junitSuite struct {
+		Name     string      `xml:"name,attr"`
+		Tests    int         `xml:"tests,attr"`
+		Failures int         `xml:"failures,attr"`
+		Time     string      `xml:"time,attr"`
+		Cases    []junitCase `xml:"testcase"`
+	}
+
+	junitCase struct {
+		Name      string        `xml:"name,attr"`
+		ClassName string        `xml:"classname,attr"`
+		Time      string        `xml:"time,attr"`
+		Failure   *junitFailure `xml:"failure,omitempty"`
+	}
+
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+// writeJUnit writes a single test suite with a test case per resource, classes are resource schemes
+func writeJUnit(w io.Writer, results []result) error {
+	suite := junitSuite{
+		Name:  "waitfor",
+		Tests: len(results),
+		Time:  seconds(elapsed(results).Seconds()),
+	}
+
+	for _, r := range results {
+		c := junitCase{
+			Name:      r.resource,
+			ClassName: r.scheme,
+			Time:      seconds(r.duration.Seconds()),
+		}
+
+		if r.err != nil {
+			suite.Failures++
+			c.Failure = &junitFailure{
+				Message: r.err.Error(),
+				Text:    fmt.Sprintf("%d attempts in %s: %s", r.attempts, r.duration, r.err),
+			}
+		}
+
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(junitSuites{Suites: []junitSuite{suite}}); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+func seconds(s float64) string {
+	return fmt.Sprintf("%.3f", s)
+}
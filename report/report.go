@@ -0,0 +1,105 @@
+// Package report serializes outcomes of a run to JSON, JUnit XML or TAP for CI tools and to a table for humans
+package report
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+const (
+	FormatJSON  Format = "json"
+	FormatJUnit Format = "junit"
+	FormatTAP   Format = "tap"
+	FormatTable Format = "table"
+)
+
+type (
+	Format string
+
+	// result is an outcome of a resource with its location stripped of a password
+	result struct {
+		resource string
+		scheme   string
+		attempts int
+		duration time.Duration
+		err      error
+	}
+
+	encoder func(w io.Writer, results []result) error
+)
+
+var encoders = map[Format]encoder{
+	FormatJSON:  writeJSON,
+	FormatJUnit: writeJUnit,
+	FormatTAP:   writeTAP,
+	FormatTable: func(w io.Writer, results []result) error { return writeTable(w, results, false) },
+}
+
+// ParseFormat returns a format by its name
+func ParseFormat(name string) (Format, error) {
+	if _, found := encoders[Format(name)]; !found {
+		return "", fmt.Errorf("%q: unknown report format %q: %w", "format", name, waitfor.ErrInvalidArgument)
+	}
+
+	return Format(name), nil
+}
+
+// Write serializes results of a run in a given format
+func Write(w io.Writer, format Format, results []waitfor.ResourceResult) error {
+	enc, found := encoders[format]
+
+	if !found {
+		return fmt.Errorf("%q: unknown report format %q: %w", "format", format, waitfor.ErrInvalidArgument)
+	}
+
+	return enc(w, convert(results))
+}
+
+// convert strips passwords of resource locations
+func convert(results []waitfor.ResourceResult) []result {
+	out := make([]result, 0, len(results))
+
+	for _, res := range results {
+		r := result{
+			resource: res.Resource,
+			attempts: res.Attempts,
+			duration: res.Duration,
+			err:      res.Err,
+		}
+
+		if u, err := url.Parse(res.Resource); err == nil {
+			r.scheme = u.Scheme
+		}
+
+		r.resource = waitfor.Redact(res.Resource)
+
+		out = append(out, r)
+	}
+
+	return out
+}
+
+// To returns a runner option writing a report once all resources are tested, write errors are ignored
+func To(w io.Writer, format Format) waitfor.Option {
+	return waitfor.WithCompletionHook(func(_ context.Context, results []waitfor.ResourceResult, _ error) {
+		_ = Write(w, format, results)
+	})
+}
+
+// elapsed returns the duration of a run, resources are tested concurrently
+func elapsed(results []result) time.Duration {
+	var longest time.Duration
+
+	for _, r := range results {
+		if r.duration > longest {
+			longest = r.duration
+		}
+	}
+
+	return longest
+}
@@ -0,0 +1,125 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+var results = []waitfor.ResourceResult{
+	{Resource: "postgres://user:secret@db:5432/app", Attempts: 2, Duration: 1500 * time.Millisecond},
+	{Resource: "redis://cache:6379", Attempts: 3, Duration: 2 * time.Second, Err: errors.New(`dial "cache": refused`)},
+}
+
+func TestWrite(t *testing.T) {
+	cases := []struct {
+		format Format
+		out    string
+	}{
+		{FormatJSON, `{
+  "ok": false,
+  "duration_ms": 2000,
+  "resources": [
+    {
+      "resource": "postgres://user:xxxxx@db:5432/app",
+      "ok": true,
+      "attempts": 2,
+      "duration_ms": 1500
+    },
+    {
+      "resource": "redis://cache:6379",
+      "ok": false,
+      "attempts": 3,
+      "duration_ms": 2000,
+      "error": "dial \"cache\": refused"
+    }
+  ]
+}
+`},
+		{FormatJUnit, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="waitfor" tests="2" failures="1" time="2.000">
+    <testcase name="postgres://user:xxxxx@db:5432/app" classname="postgres" time="1.500"></testcase>
+    <testcase name="redis://cache:6379" classname="redis" time="2.000">
+      <failure message="dial &#34;cache&#34;: refused">3 attempts in 2s: dial &#34;cache&#34;: refused</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`},
+		{FormatTAP, `TAP version 13
+1..2
+ok 1 - postgres://user:xxxxx@db:5432/app
+not ok 2 - redis://cache:6379
+  ---
+  message: "dial \"cache\": refused"
+  attempts: 3
+  duration_ms: 2000
+  ...
+`},
+	}
+
+	for _, c := range cases {
+		var buf bytes.Buffer
+
+		assert.NoError(t, Write(&buf, c.format, results), c.format)
+		assert.Equal(t, c.out, buf.String(), c.format)
+	}
+
+	assert.ErrorIs(t, Write(new(bytes.Buffer), "html", results), waitfor.ErrInvalidArgument)
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("junit")
+
+	assert.NoError(t, err)
+	assert.Equal(t, FormatJUnit, format)
+
+	_, err = ParseFormat("xml")
+
+	assert.ErrorIs(t, err, waitfor.ErrInvalidArgument)
+}
+
+type readyResource struct{}
+
+func (readyResource) Test(_ context.Context) error {
+	return nil
+}
+
+func TestTo(t *testing.T) {
+	var buf bytes.Buffer
+
+	r := waitfor.New(waitfor.ResourceConfig{
+		Scheme: []string{"ready"},
+		Factory: func(_ *url.URL) (waitfor.Resource, error) {
+			return readyResource{}, nil
+		},
+	})
+
+	assert.NoError(t, r.Test(context.Background(), []string{"ready://localhost"}, To(&buf, FormatTAP)))
+	assert.Equal(t, "TAP version 13\n1..1\nok 1 - ready://localhost\n", buf.String())
+}
+
+func TestWriteTable(t *testing.T) {
+	var buf bytes.Buffer
+
+	assert.NoError(t, Write(&buf, FormatTable, results))
+	assert.Equal(t, `RESOURCE                           STATUS  ATTEMPTS  DURATION
+postgres://user:xxxxx@db:5432/app  ready   2         1.5s
+redis://cache:6379                 failed  3         2s
+
+1 of 2 resources ready in 2s
+redis://cache:6379: dial "cache": refused
+`, buf.String())
+
+	buf.Reset()
+
+	assert.NoError(t, WriteTable(&buf, results, true))
+	assert.Contains(t, buf.String(), "postgres://user:xxxxx@db:5432/app  \x1b[32mready   \x1b[0m2")
+	assert.Contains(t, buf.String(), "redis://cache:6379                 \x1b[31mfailed  \x1b[0m3")
+}
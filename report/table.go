@@ -0,0 +1,86 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+// ANSI escape sequences of status colors
+const (
+	colorGreen = "\x1b[32m"
+	colorRed   = "\x1b[31m"
+	colorReset = "\x1b[0m"
+)
+
+// WriteTable writes a human readable table of a run with a status, attempts and duration per resource,
+// statuses are highlighted with ANSI colors if color is set
+func WriteTable(w io.Writer, results []waitfor.ResourceResult, color bool) error {
+	return writeTable(w, convert(results), color)
+}
+
+func writeTable(w io.Writer, results []result, color bool) error {
+	rows := [][]string{{"RESOURCE", "STATUS", "ATTEMPTS", "DURATION"}}
+	ready := 0
+
+	for _, r := range results {
+		status := "ready"
+
+		if r.err != nil {
+			status = "failed"
+		} else {
+			ready++
+		}
+
+		rows = append(rows, []string{r.resource, status, strconv.Itoa(r.attempts), r.duration.Round(time.Millisecond).String()})
+	}
+
+	widths := make([]int, len(rows[0]))
+
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len([]rune(cell)))
+		}
+	}
+
+	b := bufio.NewWriter(w)
+
+	for n, row := range rows {
+		for i, cell := range row {
+			// the padding is added before coloring, escape sequences have no width
+			if i < len(row)-1 {
+				cell = fmt.Sprintf("%-*s  ", widths[i], cell)
+			}
+
+			if color && n > 0 && i == 1 {
+				cell = colorize(cell, results[n-1].err == nil)
+			}
+
+			b.WriteString(cell)
+		}
+
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(b, "\n%d of %d resources ready in %s\n", ready, len(results), elapsed(results).Round(time.Millisecond))
+
+	for _, r := range results {
+		if r.err != nil {
+			fmt.Fprintf(b, "%s: %s\n", r.resource, r.err)
+		}
+	}
+
+	return b.Flush()
+}
+
+func colorize(s string, ok bool) string {
+	if ok {
+		return colorGreen + s + colorReset
+	}
+
+	return colorRed + s + colorReset
+}
@@ -0,0 +1,32 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// writeTAP writes a TAP version 13 stream with YAML diagnostics of failed resources
+func writeTAP(w io.Writer, results []result) error {
+	b := bufio.NewWriter(w)
+
+	fmt.Fprintln(b, "TAP version 13")
+	fmt.Fprintf(b, "1..%d\n", len(results))
+
+	for i, r := range results {
+		if r.err == nil {
+			fmt.Fprintf(b, "ok %d - %s\n", i+1, r.resource)
+			continue
+		}
+
+		fmt.Fprintf(b, "not ok %d - %s\n", i+1, r.resource)
+		fmt.Fprintln(b, "  ---")
+		fmt.Fprintf(b, "  message: %s\n", strconv.Quote(r.err.Error()))
+		fmt.Fprintf(b, "  attempts: %d\n", r.attempts)
+		fmt.Fprintf(b, "  duration_ms: %d\n", r.duration.Milliseconds())
+		fmt.Fprintln(b, "  ...")
+	}
+
+	return b.Flush()
+}
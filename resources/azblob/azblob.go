@@ -0,0 +1,132 @@
+// Package azblob provides a resource checking Azure Blob Storage containers and blobs
+package azblob
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+const (
+	Scheme = "azblob"
+
+	DefaultTimeout = 5 * time.Second
+
+	// ConnectionStringEnv is a connection string used instead of the credential chain
+	ConnectionStringEnv = "AZURE_STORAGE_CONNECTION_STRING"
+
+	// azuriteConnectionString is the well-known development storage account of the Azurite emulator
+	azuriteConnectionString = "DefaultEndpointsProtocol=http;AccountName=devstoreaccount1;" +
+		"AccountKey=Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw==;" +
+		"BlobEndpoint=http://127.0.0.1:10000/devstoreaccount1;"
+)
+
+// AzBlob waits for a container or, when the path is set, for a blob to exist. A connection string
+// from the environment takes precedence over the default Azure credential chain,
+// e.g. azblob://reports/2024/summary.csv?account=mystorage. Azurite is used with
+// AZURE_STORAGE_CONNECTION_STRING=UseDevelopmentStorage=true or its full connection string.
+type AzBlob struct {
+	container string
+	blob      string
+	endpoint  string
+	timeout   time.Duration
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("%q: missing container: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	a := &AzBlob{
+		container: u.Host,
+		blob:      strings.TrimPrefix(u.Path, "/"),
+		endpoint:  q.Get("endpoint"),
+	}
+
+	if account := q.Get("account"); account != "" && a.endpoint == "" {
+		a.endpoint = fmt.Sprintf("https://%s.blob.core.windows.net/", account)
+	}
+
+	if a.endpoint != "" {
+		if e, err := url.Parse(a.endpoint); err != nil || e.Host == "" {
+			return nil, fmt.Errorf("%q: invalid url: %w", "endpoint", waitfor.ErrInvalidArgument)
+		}
+	}
+
+	if a.endpoint == "" && os.Getenv(ConnectionStringEnv) == "" {
+		return nil, fmt.Errorf("%q: requires an account, an endpoint or %s: %w", "url", ConnectionStringEnv, waitfor.ErrInvalidArgument)
+	}
+
+	var err error
+
+	if a.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *AzBlob) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	client, err := a.client()
+
+	if err != nil {
+		return err
+	}
+
+	container := client.ServiceClient().NewContainerClient(a.container)
+
+	if a.blob == "" {
+		_, err = container.GetProperties(ctx, nil)
+	} else {
+		_, err = container.NewBlobClient(a.blob).GetProperties(ctx, nil)
+	}
+
+	return err
+}
+
+// client creates a service client from the connection string or the credential chain
+func (a *AzBlob) client() (*azblob.Client, error) {
+	// failed tests are retried by the runner
+	opts := &azblob.ClientOptions{}
+	opts.Retry = policy.RetryOptions{MaxRetries: -1}
+
+	if conn := os.Getenv(ConnectionStringEnv); conn != "" {
+		if strings.EqualFold(strings.TrimSuffix(conn, ";"), "UseDevelopmentStorage=true") {
+			conn = azuriteConnectionString
+		}
+
+		return azblob.NewClientFromConnectionString(conn, opts)
+	}
+
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return azblob.NewClient(a.endpoint, cred, opts)
+}
@@ -0,0 +1,79 @@
+package azblob
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAzBlob_Test(t *testing.T) {
+	// the fake server knows the reports container with the 2024/summary.csv blob
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey devstoreaccount1:") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/devstoreaccount1/reports", "/devstoreaccount1/reports/2024/summary.csv":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.Header().Set("x-ms-error-code", "ResourceNotFound")
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv(ConnectionStringEnv, strings.Replace(azuriteConnectionString, "http://127.0.0.1:10000", srv.URL, 1))
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"azblob://reports", true},
+		{"azblob://invoices", false},
+		{"azblob://reports/2024/summary.csv", true},
+		{"azblob://reports/2024/details.csv", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	u, _ := url.Parse("azblob://reports/2024/summary.csv?account=mystorage")
+	rsc, err := New(u)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://mystorage.blob.core.windows.net/", rsc.(*AzBlob).endpoint)
+	assert.Equal(t, "2024/summary.csv", rsc.(*AzBlob).blob)
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	t.Setenv(ConnectionStringEnv, "")
+
+	for _, location := range []string{"azblob:///blob", "azblob://reports", "azblob://reports?endpoint=localhost", "azblob://reports?timeout=x"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
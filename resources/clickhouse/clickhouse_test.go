@@ -0,0 +1,101 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClickHouse_Test(t *testing.T) {
+	// the fake server knows app.events table and default.geo dictionary
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user := r.Header.Get("X-ClickHouse-User"); user != "" && r.Header.Get("X-ClickHouse-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintln(w, "Code: 516. Authentication failed")
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		statement := string(body)
+		q := r.URL.Query()
+
+		database := q.Get("param_database")
+
+		if database == "" {
+			database = q.Get("database")
+		}
+
+		if database == "" {
+			database = "default"
+		}
+
+		switch {
+		case statement == "SELECT 1":
+			fmt.Fprintln(w, "1")
+		case strings.Contains(statement, "system.tables"):
+			fmt.Fprintln(w, boolToCount(database == "app" && q.Get("param_name") == "events"))
+		case strings.Contains(statement, "system.dictionaries"):
+			fmt.Fprintln(w, boolToCount(database == "default" && q.Get("param_name") == "geo"))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"clickhouse://" + host, true},
+		{"clickhouse://user:secret@" + host, true},
+		{"clickhouse://user:wrong@" + host, false},
+		{"clickhouse://" + host + "/app?table=events", true},
+		{"clickhouse://" + host + "?table=app.events", true},
+		{"clickhouse://" + host + "?table=events", false},
+		{"clickhouse://" + host + "?dictionary=geo", true},
+		{"clickhouse://" + host + "/app?dictionary=geo", false},
+		{"clickhouse://" + host + "/app?dictionary=default.geo&table=events", true},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func boolToCount(b bool) string {
+	if b {
+		return "1"
+	}
+
+	return "0"
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"clickhouse://localhost?table=app.", "clickhouse://localhost?tls=maybe", "clickhouse://localhost?tls=true&ca=missing.pem"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
@@ -0,0 +1,147 @@
+// Package consul provides a resource checking service health registered in Consul
+package consul
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"github.com/go-waitfor/waitfor/internal/tlsconfig"
+)
+
+const (
+	Scheme = "consul"
+
+	DefaultTimeout = 5 * time.Second
+
+	maxResponseSize = 4 * 1024 * 1024
+)
+
+// Consul waits until a service has at least a given number of instances with passing checks,
+// e.g. consul://localhost:8500/payments?min=2&tag=v2&dc=eu&token=secret
+type Consul struct {
+	url     *url.URL
+	token   string
+	min     int
+	timeout time.Duration
+	client  *http.Client
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	service := strings.Trim(u.Path, "/")
+
+	if service == "" || strings.Contains(service, "/") {
+		return nil, fmt.Errorf("%q: path must be a service name: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	c := &Consul{
+		url:   &url.URL{Scheme: "http", Host: u.Host},
+		token: q.Get("token"),
+	}
+
+	if u.Port() == "" {
+		c.url.Host = net.JoinHostPort(u.Hostname(), "8500")
+	}
+
+	c.url = c.url.JoinPath("v1/health/service", service)
+
+	api := url.Values{"passing": {"true"}}
+
+	for _, p := range []string{"tag", "dc", "ns", "partition"} {
+		if values, ok := q[p]; ok {
+			api[p] = values
+		}
+	}
+
+	c.url.RawQuery = api.Encode()
+
+	var err error
+
+	if c.min, err = query.Int(q, "min", 1); err != nil {
+		return nil, err
+	}
+
+	if c.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	secure, err := query.Bool(q, "tls", false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if secure {
+		c.url.Scheme = "https"
+
+		if transport.TLSClientConfig, err = tlsconfig.FromQuery(q, &tls.Config{}); err != nil {
+			return nil, err
+		}
+	}
+
+	c.client = &http.Client{Transport: transport}
+
+	return c, nil
+}
+
+func (c *Consul) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url.String(), nil)
+
+	if err != nil {
+		return err
+	}
+
+	if c.token != "" {
+		req.Header.Set("X-Consul-Token", c.token)
+	}
+
+	res, err := c.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	var instances []json.RawMessage
+
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxResponseSize)).Decode(&instances); err != nil {
+		return err
+	}
+
+	if len(instances) < c.min {
+		return fmt.Errorf("%d passing instances, expected at least %d", len(instances), c.min)
+	}
+
+	return nil
+}
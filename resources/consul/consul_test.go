@@ -0,0 +1,75 @@
+package consul
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsul_Test(t *testing.T) {
+	// payments has two passing instances, one of them tagged v2
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Consul-Token") == "wrong" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		if r.URL.Path != "/v1/health/service/payments" || r.URL.Query().Get("passing") != "true" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+
+		if r.URL.Query().Get("tag") == "v2" {
+			fmt.Fprint(w, `[{"Service":{"ID":"payments-2"}}]`)
+			return
+		}
+
+		fmt.Fprint(w, `[{"Service":{"ID":"payments-1"}},{"Service":{"ID":"payments-2"}}]`)
+	}))
+	defer srv.Close()
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"consul://" + host + "/payments", true},
+		{"consul://" + host + "/payments?min=2", true},
+		{"consul://" + host + "/payments?min=3", false},
+		{"consul://" + host + "/payments?tag=v2&min=2", false},
+		{"consul://" + host + "/orders", false},
+		{"consul://" + host + "/payments?token=wrong", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"consul://localhost", "consul://localhost/a/b", "consul://localhost/payments?min=x", "consul://localhost/payments?tls=true&ca=missing.pem"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
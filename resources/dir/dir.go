@@ -0,0 +1,117 @@
+// Package dir provides resources waiting for directories and files matching a pattern
+package dir
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+const (
+	Scheme     = "dir"
+	GlobScheme = "glob"
+)
+
+type (
+	// Dir checks that a directory exists and has at least a given number of entries,
+	// e.g. dir:///data/incoming?min=1
+	Dir struct {
+		path string
+		min  int
+	}
+
+	// Glob checks that at least a given number of files match a pattern,
+	// e.g. glob:///data/incoming/*.csv?min=2
+	Glob struct {
+		pattern string
+		min     int
+	}
+)
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme, GlobScheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	path := u.Host + u.Path
+
+	if path == "" {
+		return nil, fmt.Errorf("%q: %w", "path", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Scheme == GlobScheme {
+		if _, err := filepath.Match(path, ""); err != nil {
+			return nil, fmt.Errorf("%q: %s: %w", "pattern", err, waitfor.ErrInvalidArgument)
+		}
+
+		count, err := query.Int(u.Query(), "min", 1)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &Glob{pattern: path, min: count}, nil
+	}
+
+	count, err := query.Int(u.Query(), "min", 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Dir{path: path, min: count}, nil
+}
+
+func (d *Dir) Test(_ context.Context) error {
+	info, err := os.Stat(d.path)
+
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("%s: not a directory", d.path)
+	}
+
+	if d.min <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(d.path)
+
+	if err != nil {
+		return err
+	}
+
+	if len(entries) < d.min {
+		return fmt.Errorf("%s: %d entries found, expected at least %d", d.path, len(entries), d.min)
+	}
+
+	return nil
+}
+
+func (g *Glob) Test(_ context.Context) error {
+	matches, err := filepath.Glob(g.pattern)
+
+	if err != nil {
+		return err
+	}
+
+	if len(matches) < g.min {
+		return fmt.Errorf("%s: %d files found, expected at least %d", g.pattern, len(matches), g.min)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package dir
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDir_Test(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.csv"), nil, 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), nil, 0600))
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"dir://" + dir, true},
+		{"dir://" + dir + "?min=2", true},
+		{"dir://" + dir + "?min=3", false},
+		{"dir://" + filepath.Join(dir, "missing"), false},
+		{"dir://" + filepath.Join(dir, "a.csv"), false},
+		{"glob://" + dir + "/*.csv", true},
+		{"glob://" + dir + "/*.csv?min=2", false},
+		{"glob://" + dir + "/*.json", false},
+		{"glob://" + dir + "/*.json?min=0", true},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidPattern(t *testing.T) {
+	u, _ := url.Parse("glob:///data/[")
+	_, err := New(u)
+
+	assert.ErrorIs(t, err, waitfor.ErrInvalidArgument)
+}
@@ -0,0 +1,123 @@
+// Package disk provides a resource waiting for free space on a file system
+package disk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+const Scheme = "disk"
+
+// units are size suffixes, decimal and binary ones are both accepted
+var units = []struct {
+	suffix string
+	size   uint64
+}{
+	{"KiB", 1 << 10},
+	{"MiB", 1 << 20},
+	{"GiB", 1 << 30},
+	{"TiB", 1 << 40},
+	{"KB", 1e3},
+	{"MB", 1e6},
+	{"GB", 1e9},
+	{"TB", 1e12},
+	{"B", 1},
+}
+
+// Disk waits for a file system to have a minimum of space available to unprivileged users,
+// either as a size, e.g. disk:///var/lib/data?minFree=5GB, or as a share, e.g. disk:///var/lib/data?minFree=10%
+type Disk struct {
+	path    string
+	bytes   uint64
+	percent float64
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	d := &Disk{path: u.Host + u.Path}
+
+	if d.path == "" {
+		return nil, fmt.Errorf("%q: %w", "path", waitfor.ErrInvalidArgument)
+	}
+
+	value := u.Query().Get("minFree")
+
+	if value == "" {
+		return nil, fmt.Errorf("%q: missing value: %w", "minFree", waitfor.ErrInvalidArgument)
+	}
+
+	if percent, ok := strings.CutSuffix(value, "%"); ok {
+		p, err := strconv.ParseFloat(percent, 64)
+
+		if err != nil || p < 0 || p > 100 {
+			return nil, fmt.Errorf("%q: invalid percentage %q: %w", "minFree", value, waitfor.ErrInvalidArgument)
+		}
+
+		d.percent = p
+
+		return d, nil
+	}
+
+	size, err := parseSize(value)
+
+	if err != nil {
+		return nil, fmt.Errorf("%q: %s: %w", "minFree", err, waitfor.ErrInvalidArgument)
+	}
+
+	d.bytes = size
+
+	return d, nil
+}
+
+// parseSize parses sizes like 512MiB, 5GB or 1.5TB, plain numbers are bytes
+func parseSize(s string) (uint64, error) {
+	number, unit := s, uint64(1)
+
+	for _, u := range units {
+		if n, ok := strings.CutSuffix(s, u.suffix); ok {
+			number, unit = strings.TrimSpace(n), u.size
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(number, 64)
+
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+
+	return uint64(n * float64(unit)), nil
+}
+
+func (d *Disk) Test(_ context.Context) error {
+	free, total, err := usage(d.path)
+
+	if err != nil {
+		return err
+	}
+
+	if free < d.bytes {
+		return fmt.Errorf("%s has %d bytes free, expected at least %d", d.path, free, d.bytes)
+	}
+
+	if total > 0 && float64(free)/float64(total)*100 < d.percent {
+		return fmt.Errorf("%s has %.1f%% free, expected at least %g%%", d.path, float64(free)/float64(total)*100, d.percent)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package disk
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisk_Test(t *testing.T) {
+	dir := t.TempDir()
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"disk://" + dir + "?minFree=1B", true},
+		{"disk://" + dir + "?minFree=0%25", true},
+		{"disk://" + dir + "?minFree=1000000TB", false},
+		{"disk://" + dir + "?minFree=100%25", false},
+		{"disk://" + dir + "/missing?minFree=1B", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	cases := map[string]uint64{
+		"512":    512,
+		"10B":    10,
+		"2KB":    2000,
+		"2KiB":   2048,
+		"1.5GB":  1500000000,
+		"5 GiB":  5 << 30,
+		"0.5TiB": 1 << 39,
+	}
+
+	for s, expected := range cases {
+		size, err := parseSize(s)
+
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, size, s)
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"disk://?minFree=1GB", "disk:///data", "disk:///data?minFree=lots", "disk:///data?minFree=-1GB", "disk:///data?minFree=120%25"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
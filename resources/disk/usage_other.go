@@ -0,0 +1,9 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux
+
+package disk
+
+import "github.com/go-waitfor/waitfor"
+
+func usage(_ string) (uint64, uint64, error) {
+	return 0, 0, waitfor.ErrNotSupported
+}
@@ -0,0 +1,16 @@
+//go:build darwin || dragonfly || freebsd || linux
+
+package disk
+
+import "syscall"
+
+// usage returns available and total bytes of the file system containing a path
+func usage(path string) (uint64, uint64, error) {
+	var stat syscall.Statfs_t
+
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+
+	return uint64(stat.Bavail) * uint64(stat.Bsize), uint64(stat.Blocks) * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,88 @@
+// Package dynamodb provides a resource checking AWS DynamoDB tables
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/awsconfig"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+const (
+	Scheme = "dynamodb"
+
+	DefaultTimeout = 5 * time.Second
+)
+
+// DynamoDB waits for a table to reach the ACTIVE status, e.g. dynamodb://sessions?region=eu-west-1
+// or dynamodb://sessions?endpoint=http://localhost:8000 for DynamoDB Local
+type DynamoDB struct {
+	table   string
+	aws     awsconfig.Params
+	timeout time.Duration
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Host == "" || strings.Trim(u.Path, "/") != "" {
+		return nil, fmt.Errorf("%q: host must be a table name: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	d := &DynamoDB{table: u.Host}
+
+	var err error
+
+	if d.aws, err = awsconfig.FromQuery(q); err != nil {
+		return nil, err
+	}
+
+	if d.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (d *DynamoDB) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	cfg, err := d.aws.Load(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	out, err := dynamodb.NewFromConfig(cfg).DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(d.table),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if status := out.Table.TableStatus; status != types.TableStatusActive {
+		return fmt.Errorf("table %s is %s", d.table, strings.ToLower(string(status)))
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package dynamodb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamoDB_Test(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "local")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "local")
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_CONFIG_FILE", "/dev/null")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/dev/null")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+	// the fake server knows the active sessions table and the users table being created
+	tables := map[string]string{"sessions": "ACTIVE", "users": "CREATING"}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+
+		status, ok := tables[body["TableName"]]
+
+		if r.Header.Get("X-Amz-Target") != "DynamoDB_20120810.DescribeTable" || !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"com.amazonaws.dynamodb.v20120810#ResourceNotFoundException","message":"Requested resource not found"}`))
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"Table": map[string]string{"TableName": body["TableName"], "TableStatus": status},
+		})
+	}))
+	defer srv.Close()
+
+	endpoint := "?endpoint=" + url.QueryEscape(srv.URL)
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"dynamodb://sessions" + endpoint, true},
+		{"dynamodb://users" + endpoint, false},
+		{"dynamodb://orders" + endpoint, false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"dynamodb://", "dynamodb://sessions/extra", "dynamodb://sessions?endpoint=localhost", "dynamodb://sessions?timeout=x"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
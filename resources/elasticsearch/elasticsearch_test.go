@@ -0,0 +1,78 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(t *testing.T, status string) string {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/_cluster/health":
+			if user, pass, ok := r.BasicAuth(); ok && (user != "elastic" || pass != "secret") {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			fmt.Fprintf(w, `{"cluster_name":"test","status":%q}`, status)
+		case "/logs":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+func TestElasticsearch_Test(t *testing.T) {
+	yellow := serve(t, "yellow")
+	green := serve(t, "green")
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"elasticsearch://" + yellow, true},
+		{"elasticsearch://" + yellow + "?status=green", false},
+		{"opensearch://" + green + "?status=green", true},
+		{"elasticsearch://" + green + "?index=logs", true},
+		{"elasticsearch://" + green + "?index=metrics", false},
+		{"elasticsearch://elastic:secret@" + green, true},
+		{"elasticsearch://elastic:wrong@" + green, false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"elasticsearch://localhost?status=blue", "elasticsearch://localhost?tls=maybe", "opensearch://localhost?tls=true&ca=missing.pem"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
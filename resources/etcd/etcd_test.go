@@ -0,0 +1,108 @@
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+// serve starts a fake member which stores the "ready" key and accepts root:secret credentials
+func serve(t *testing.T, healthy bool) string {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			if !healthy {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				fmt.Fprint(w, `{"health":"false","reason":"RAFT NO LEADER"}`)
+				return
+			}
+
+			fmt.Fprint(w, `{"health":"true","reason":""}`)
+		case "/v3/auth/authenticate":
+			var req map[string]string
+
+			_ = json.NewDecoder(r.Body).Decode(&req)
+
+			if req["name"] != "root" || req["password"] != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"error":"authentication failed, invalid user ID or password"}`)
+				return
+			}
+
+			fmt.Fprint(w, `{"token":"t0ken"}`)
+		case "/v3/kv/range":
+			var req map[string]interface{}
+
+			_ = json.NewDecoder(r.Body).Decode(&req)
+
+			// "cmVhZHk=" is base64 encoded "ready"
+			if req["key"] == "cmVhZHk=" {
+				fmt.Fprint(w, `{"header":{},"count":"1"}`)
+				return
+			}
+
+			fmt.Fprint(w, `{"header":{}}`)
+		}
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+func TestEtcd_Test(t *testing.T) {
+	up1, up2 := serve(t, true), serve(t, true)
+	noLeader := serve(t, false)
+
+	l, _ := net.Listen("tcp", "127.0.0.1:0")
+	down := l.Addr().String()
+	l.Close()
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"etcd://" + up1, true},
+		{"etcd://" + noLeader, false},
+		{"etcd://" + down + "," + up1, true},
+		{"etcd://" + up1 + "," + up2 + "," + noLeader + "?quorum=true", true},
+		{"etcd://" + up1 + "," + down + "," + noLeader + "?quorum=true", false},
+		{"etcd://" + up1 + "?key=ready", true},
+		{"etcd://" + up1 + "?key=missing", false},
+		{"etcd://root:secret@" + up1 + "?key=ready", true},
+		{"etcd://root:wrong@" + up1 + "?key=ready", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"etcd://node1,,node2", "etcd://node?quorum=maybe", "etcd://node?tls=true&ca=missing.pem"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
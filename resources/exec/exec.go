@@ -0,0 +1,93 @@
+// Package exec provides a resource running a command
+package exec
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	osexec "os/exec"
+	"strings"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+const (
+	Scheme    = "exec"
+	CmdScheme = "cmd"
+
+	DefaultTimeout = 10 * time.Second
+)
+
+// Exec runs a command and treats exit code 0 as ready,
+// e.g. exec://pg_isready?arg=-h&arg=db or exec:///usr/local/bin/check?env=MODE=strict
+type Exec struct {
+	executable string
+	args       []string
+	env        []string
+	timeout    time.Duration
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme, CmdScheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	executable := u.Host + u.Path
+
+	if executable == "" {
+		return nil, fmt.Errorf("%q: %w", "executable", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	timeout, err := query.Duration(q, "timeout", DefaultTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Exec{
+		executable: executable,
+		args:       q["arg"],
+		env:        q["env"],
+		timeout:    timeout,
+	}, nil
+}
+
+func (e *Exec) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	cmd := osexec.CommandContext(ctx, e.executable, e.args...)
+	// do not wait for output of orphaned children once the command is killed
+	cmd.WaitDelay = time.Second
+
+	if len(e.env) > 0 {
+		cmd.Env = append(os.Environ(), e.env...)
+	}
+
+	var out bytes.Buffer
+
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	if err := cmd.Run(); err != nil {
+		if output := strings.TrimSpace(out.String()); output != "" {
+			return fmt.Errorf("%w: %s", err, output)
+		}
+
+		return err
+	}
+
+	return nil
+}
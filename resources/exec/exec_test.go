@@ -0,0 +1,45 @@
+package exec
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExec_Test(t *testing.T) {
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"exec://true", true},
+		{"exec://false", false},
+		{"cmd:///bin/sh?arg=-c&arg=exit+0", true},
+		{"exec://sh?arg=-c&arg=test+%22$MODE%22+%3D+strict&env=MODE%3Dstrict", true},
+		{"exec://sh?arg=-c&arg=sleep+5&timeout=100ms", false},
+		{"exec://missing-executable", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestExec_Test_Output(t *testing.T) {
+	u, _ := url.Parse("exec://sh?arg=-c&arg=echo+not+ready%3B+exit+2")
+	rsc, _ := New(u)
+
+	assert.EqualError(t, rsc.Test(context.Background()), "exit status 2: not ready")
+}
@@ -0,0 +1,114 @@
+// Package file provides a resource checking a file and its attributes
+package file
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+const Scheme = "file"
+
+// File checks that a file exists and optionally its size, freshness, readability and content,
+// e.g. file:///var/run/app.pid?nonEmpty=true or file://./credentials.json?maxAge=5m&regex=token
+type File struct {
+	path     string
+	nonEmpty bool
+	minSize  int
+	maxAge   time.Duration
+	readable bool
+	regex    *regexp.Regexp
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	path := u.Host + u.Path
+
+	if path == "" {
+		return nil, fmt.Errorf("%q: %w", "path", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	f := &File{path: path}
+
+	var err error
+
+	if f.nonEmpty, err = query.Bool(q, "nonEmpty", false); err != nil {
+		return nil, err
+	}
+
+	if f.minSize, err = query.Int(q, "minSize", 0); err != nil {
+		return nil, err
+	}
+
+	if f.maxAge, err = query.Duration(q, "maxAge", 0); err != nil {
+		return nil, err
+	}
+
+	if f.readable, err = query.Bool(q, "readable", false); err != nil {
+		return nil, err
+	}
+
+	if value := q.Get("regex"); value != "" {
+		if f.regex, err = regexp.Compile(value); err != nil {
+			return nil, fmt.Errorf("%q: %s: %w", "regex", err, waitfor.ErrInvalidArgument)
+		}
+	}
+
+	return f, nil
+}
+
+func (f *File) Test(_ context.Context) error {
+	info, err := os.Stat(f.path)
+
+	if err != nil {
+		return err
+	}
+
+	if f.nonEmpty && info.Size() == 0 {
+		return fmt.Errorf("%s: file is empty", f.path)
+	}
+
+	if info.Size() < int64(f.minSize) {
+		return fmt.Errorf("%s: size %d is less than %d", f.path, info.Size(), f.minSize)
+	}
+
+	if f.maxAge > 0 && time.Since(info.ModTime()) > f.maxAge {
+		return fmt.Errorf("%s: modified at %s", f.path, info.ModTime().Format(time.RFC3339))
+	}
+
+	if !f.readable && f.regex == nil {
+		return nil
+	}
+
+	file, err := os.Open(f.path)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	if f.regex != nil && !f.regex.MatchReader(bufio.NewReader(file)) {
+		return fmt.Errorf("%s: content does not match %q", f.path, f.regex)
+	}
+
+	return nil
+}
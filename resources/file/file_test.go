@@ -0,0 +1,54 @@
+package file
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFile_Test(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty")
+	full := filepath.Join(dir, "full")
+	old := filepath.Join(dir, "old")
+
+	assert.NoError(t, os.WriteFile(empty, nil, 0600))
+	assert.NoError(t, os.WriteFile(full, []byte("token=abc"), 0600))
+	assert.NoError(t, os.WriteFile(old, []byte("old"), 0600))
+	assert.NoError(t, os.Chtimes(old, time.Now().Add(-time.Hour), time.Now().Add(-time.Hour)))
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"file://" + empty, true},
+		{"file://" + filepath.Join(dir, "missing"), false},
+		{"file://" + empty + "?nonEmpty=true", false},
+		{"file://" + full + "?nonEmpty=true&readable=true", true},
+		{"file://" + full + "?minSize=100", false},
+		{"file://" + old + "?maxAge=5m", false},
+		{"file://" + full + "?maxAge=5m", true},
+		{"file://" + full + "?regex=token=[a-z]%2B", true},
+		{"file://" + full + "?regex=^password", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package ftp
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+// serve starts a fake server accepting user:secret and anonymous logins with an /incoming directory
+func serve(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			c, err := l.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go func() {
+				conn := textproto.NewConn(c)
+				defer conn.Close()
+
+				_ = conn.PrintfLine("220 ready")
+
+				var user string
+
+				for {
+					line, err := conn.ReadLine()
+
+					if err != nil {
+						return
+					}
+
+					var arg string
+					var cmd string
+
+					fmt.Sscanf(line, "%s %s", &cmd, &arg)
+
+					switch {
+					case cmd == "USER":
+						user = arg
+						_ = conn.PrintfLine("331 password required")
+					case cmd == "PASS" && (user == "anonymous" || user == "user" && arg == "secret"):
+						_ = conn.PrintfLine("230 logged in")
+					case cmd == "PASS":
+						_ = conn.PrintfLine("530 login incorrect")
+					case cmd == "CWD" && arg == "/incoming":
+						_ = conn.PrintfLine("250 directory changed")
+					case cmd == "SIZE" && arg == "/incoming/data.csv":
+						_ = conn.PrintfLine("213 42")
+					case cmd == "QUIT":
+						_ = conn.PrintfLine("221 bye")
+						return
+					default:
+						_ = conn.PrintfLine("550 not found")
+					}
+				}
+			}()
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func TestFTP_Test(t *testing.T) {
+	addr := serve(t)
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"ftp://" + addr, true},
+		{"ftp://user:secret@" + addr, true},
+		{"ftp://user:wrong@" + addr, false},
+		{"ftp://" + addr + "/incoming", true},
+		{"ftp://user:secret@" + addr + "/incoming/data.csv", true},
+		{"ftp://" + addr + "/outgoing", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+// fakeSubsystem answers sftp requests knowing only the /upload path
+type fakeSubsystem struct {
+	in  *io.PipeWriter
+	out *io.PipeReader
+}
+
+func newFakeSubsystem() *fakeSubsystem {
+	reqR, reqW := io.Pipe()
+	resR, resW := io.Pipe()
+
+	go func() {
+		for {
+			kind, data, err := readPacket(reqR)
+
+			if err != nil {
+				return
+			}
+
+			switch kind {
+			case fxpInit:
+				_ = writePacket(resW, fxpVersion, binary.BigEndian.AppendUint32(nil, 3))
+			case fxpStat:
+				id := data[:4]
+				path := string(data[8:])
+
+				if path == "/upload" {
+					_ = writePacket(resW, fxpAttrs, append(id, 0, 0, 0, 0))
+				} else {
+					_ = writePacket(resW, fxpStatus, binary.BigEndian.AppendUint32(id, fxNoSuchFile))
+				}
+			}
+		}
+	}()
+
+	return &fakeSubsystem{in: reqW, out: resR}
+}
+
+func (f *fakeSubsystem) StdinPipe() (io.WriteCloser, error) { return f.in, nil }
+func (f *fakeSubsystem) StdoutPipe() (io.Reader, error)     { return f.out, nil }
+func (f *fakeSubsystem) RequestSubsystem(string) error      { return nil }
+
+func TestStat(t *testing.T) {
+	assert.NoError(t, stat(newFakeSubsystem(), ""))
+	assert.NoError(t, stat(newFakeSubsystem(), "/upload"))
+	assert.Error(t, stat(newFakeSubsystem(), "/download"))
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"ftp://localhost?timeout=x", "sftp://localhost?key=missing", "sftp://localhost?knownHosts=missing"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
@@ -0,0 +1,126 @@
+package ftp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// a subset of the SFTP version 3 protocol used to check a path
+const (
+	fxpInit    = 1
+	fxpVersion = 2
+	fxpStat    = 17
+	fxpStatus  = 101
+	fxpAttrs   = 105
+
+	fxNoSuchFile = 2
+
+	maxPacketSize = 256 * 1024
+)
+
+// subsystem is a session able to start the sftp subsystem
+type subsystem interface {
+	StdinPipe() (io.WriteCloser, error)
+	StdoutPipe() (io.Reader, error)
+	RequestSubsystem(name string) error
+}
+
+// stat starts the sftp subsystem, negotiates the version and stats a path
+func stat(session subsystem, path string) error {
+	w, err := session.StdinPipe()
+
+	if err != nil {
+		return err
+	}
+
+	r, err := session.StdoutPipe()
+
+	if err != nil {
+		return err
+	}
+
+	if err := session.RequestSubsystem("sftp"); err != nil {
+		return fmt.Errorf("sftp subsystem: %w", err)
+	}
+
+	if err := writePacket(w, fxpInit, binary.BigEndian.AppendUint32(nil, 3)); err != nil {
+		return err
+	}
+
+	kind, _, err := readPacket(r)
+
+	if err != nil {
+		return err
+	}
+
+	if kind != fxpVersion {
+		return fmt.Errorf("sftp: unexpected packet type %d", kind)
+	}
+
+	if path == "" {
+		return nil
+	}
+
+	req := binary.BigEndian.AppendUint32(nil, 1)
+	req = binary.BigEndian.AppendUint32(req, uint32(len(path)))
+	req = append(req, path...)
+
+	if err := writePacket(w, fxpStat, req); err != nil {
+		return err
+	}
+
+	kind, data, err := readPacket(r)
+
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case kind == fxpAttrs:
+		return nil
+	case kind == fxpStatus && len(data) >= 8:
+		code := binary.BigEndian.Uint32(data[4:])
+
+		if code == fxNoSuchFile {
+			return fmt.Errorf("path %q does not exist", path)
+		}
+
+		return fmt.Errorf("stat %q: status %d", path, code)
+	}
+
+	return fmt.Errorf("sftp: unexpected packet type %d", kind)
+}
+
+func writePacket(w io.Writer, kind byte, payload []byte) error {
+	packet := binary.BigEndian.AppendUint32(nil, uint32(len(payload)+1))
+	packet = append(append(packet, kind), payload...)
+
+	_, err := w.Write(packet)
+
+	return err
+}
+
+// readPacket returns a packet type and its payload
+func readPacket(r io.Reader) (byte, []byte, error) {
+	header := make([]byte, 5)
+
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header)
+
+	if size < 1 || size > maxPacketSize {
+		return 0, nil, errors.New("sftp: invalid packet size")
+	}
+
+	data := make([]byte, size-1)
+
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, nil, err
+	}
+
+	return header[4], data, nil
+}
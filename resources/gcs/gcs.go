@@ -0,0 +1,133 @@
+// Package gcs provides a resource checking Google Cloud Storage buckets and objects
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	Scheme = "gs"
+
+	DefaultTimeout  = 5 * time.Second
+	DefaultEndpoint = "https://storage.googleapis.com"
+
+	// EmulatorHostEnv points the resource to an emulator, requests are not authenticated then
+	EmulatorHostEnv = "STORAGE_EMULATOR_HOST"
+
+	readOnlyScope = "https://www.googleapis.com/auth/devstorage.read_only"
+)
+
+// GCS waits for a bucket or, when the path is set, for an object to exist. Requests are
+// authenticated with Application Default Credentials unless an emulator is used,
+// e.g. gs://reports/2024/summary.csv or gs://data?endpoint=http://localhost:4443
+type GCS struct {
+	name     string
+	url      *url.URL
+	emulator bool
+	timeout  time.Duration
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("%q: missing bucket: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	endpoint, emulator := DefaultEndpoint, false
+
+	if host := os.Getenv(EmulatorHostEnv); host != "" {
+		endpoint, emulator = host, true
+
+		if !strings.Contains(host, "://") {
+			endpoint = "http://" + host
+		}
+	}
+
+	if q.Has("endpoint") {
+		endpoint, emulator = q.Get("endpoint"), true
+	}
+
+	base, err := url.Parse(endpoint)
+
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("%q: invalid url: %w", "endpoint", waitfor.ErrInvalidArgument)
+	}
+
+	g := &GCS{
+		name:     u.Host + u.Path,
+		url:      base.JoinPath("storage/v1/b", u.Host),
+		emulator: emulator,
+	}
+
+	// object names may contain slashes which must be escaped as a single path segment
+	if object := strings.TrimPrefix(u.Path, "/"); object != "" {
+		g.url = g.url.JoinPath("o")
+		g.url.RawPath = g.url.Path + "/" + url.PathEscape(object)
+		g.url.Path += "/" + object
+	}
+
+	if g.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func (g *GCS) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	client := http.DefaultClient
+
+	if !g.emulator {
+		var err error
+
+		if client, err = google.DefaultClient(ctx, readOnlyScope); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.url.String(), nil)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("%s does not exist", g.name)
+	}
+
+	return fmt.Errorf("unexpected status code %d", res.StatusCode)
+}
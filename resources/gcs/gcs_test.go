@@ -0,0 +1,73 @@
+package gcs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCS_Test(t *testing.T) {
+	// the fake server knows the reports bucket with the 2024/summary.csv object
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/storage/v1/b/reports", "/storage/v1/b/reports/o/2024%2Fsummary.csv":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv(EmulatorHostEnv, srv.Listener.Addr().String())
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"gs://reports", true},
+		{"gs://invoices", false},
+		{"gs://reports/2024/summary.csv", true},
+		{"gs://reports/2024/details.csv", false},
+		{"gs://reports?endpoint=" + url.QueryEscape(srv.URL), true},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv(EmulatorHostEnv, "")
+
+	u, _ := url.Parse("gs://reports/2024/summary.csv")
+	rsc, err := New(u)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://storage.googleapis.com/storage/v1/b/reports/o/2024%2Fsummary.csv", rsc.(*GCS).url.String())
+	assert.False(t, rsc.(*GCS).emulator)
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"gs:///object", "gs://bucket?endpoint=localhost", "gs://bucket?timeout=x"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
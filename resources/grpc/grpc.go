@@ -0,0 +1,241 @@
+// Package grpc provides a resource implementing the grpc.health.v1 health checking protocol
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"github.com/go-waitfor/waitfor/internal/tlsconfig"
+)
+
+const (
+	Scheme       = "grpc"
+	SecureScheme = "grpcs"
+
+	DefaultTimeout = 5 * time.Second
+
+	checkPath = "/grpc.health.v1.Health/Check"
+)
+
+// serving statuses of grpc.health.v1.HealthCheckResponse
+var statuses = map[uint64]string{
+	0: "UNKNOWN",
+	1: "SERVING",
+	2: "NOT_SERVING",
+	3: "SERVICE_UNKNOWN",
+}
+
+// GRPC calls grpc.health.v1.Health/Check and expects SERVING status,
+// e.g. grpc://localhost:50051?service=orders.v1.Orders&authority=orders.internal
+// or grpcs://localhost:50051?ca=/etc/ssl/ca.pem
+type GRPC struct {
+	url       *url.URL
+	service   string
+	authority string
+	client    *http.Client
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme, SecureScheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Hostname() == "" || u.Port() == "" {
+		return nil, fmt.Errorf("%q: host and port are required: %w", u.Host, waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	timeout, err := query.Duration(q, "timeout", DefaultTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := tlsconfig.FromQuery(q, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.Protocols = new(http.Protocols)
+
+	target := &url.URL{Scheme: "https", Host: u.Host, Path: checkPath}
+
+	if u.Scheme == SecureScheme {
+		transport.Protocols.SetHTTP2(true)
+	} else {
+		target.Scheme = "http"
+		transport.Protocols.SetUnencryptedHTTP2(true)
+	}
+
+	return &GRPC{
+		url:       target,
+		service:   q.Get("service"),
+		authority: q.Get("authority"),
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+		},
+	}, nil
+}
+
+func (g *GRPC) Test(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.url.String(), bytes.NewReader(encodeRequest(g.service)))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("TE", "trailers")
+
+	if g.authority != "" {
+		req.Host = g.authority
+	}
+
+	res, err := g.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 64*1024))
+
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected http status: %s", res.Status)
+	}
+
+	if err := checkStatus(res); err != nil {
+		return err
+	}
+
+	status, err := decodeResponse(body)
+
+	if err != nil {
+		return err
+	}
+
+	if status != 1 {
+		return fmt.Errorf("service status is %s", statuses[status])
+	}
+
+	return nil
+}
+
+// checkStatus checks grpc-status sent in trailers or in headers of trailers-only responses
+func checkStatus(res *http.Response) error {
+	status := res.Trailer.Get("Grpc-Status")
+	message := res.Trailer.Get("Grpc-Message")
+
+	if status == "" {
+		status = res.Header.Get("Grpc-Status")
+		message = res.Header.Get("Grpc-Message")
+	}
+
+	if status != "" && status != "0" {
+		msg, _ := url.PathUnescape(message)
+
+		return fmt.Errorf("grpc status %s: %s", status, msg)
+	}
+
+	return nil
+}
+
+// encodeRequest encodes a length-prefixed HealthCheckRequest message
+func encodeRequest(service string) []byte {
+	msg := make([]byte, 0, len(service)+2)
+
+	if service != "" {
+		msg = append(msg, 0x0a)
+		msg = binary.AppendUvarint(msg, uint64(len(service)))
+		msg = append(msg, service...)
+	}
+
+	frame := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+
+	return append(frame, msg...)
+}
+
+// decodeResponse decodes a status of a length-prefixed HealthCheckResponse message
+func decodeResponse(body []byte) (uint64, error) {
+	if len(body) < 5 {
+		return 0, errors.New("malformed grpc response")
+	}
+
+	if body[0] != 0 {
+		return 0, errors.New("compressed grpc responses are not supported")
+	}
+
+	size := binary.BigEndian.Uint32(body[1:5])
+	msg := body[5:]
+
+	if uint32(len(msg)) < size {
+		return 0, errors.New("truncated grpc response")
+	}
+
+	msg = msg[:size]
+
+	var status uint64
+
+	for len(msg) > 0 {
+		key, n := binary.Uvarint(msg)
+
+		if n <= 0 {
+			return 0, errors.New("malformed health check response")
+		}
+
+		msg = msg[n:]
+
+		switch key & 7 {
+		case 0:
+			value, n := binary.Uvarint(msg)
+
+			if n <= 0 {
+				return 0, errors.New("malformed health check response")
+			}
+
+			if key>>3 == 1 {
+				status = value
+			}
+
+			msg = msg[n:]
+		case 2:
+			size, n := binary.Uvarint(msg)
+
+			if n <= 0 || uint64(len(msg)-n) < size {
+				return 0, errors.New("malformed health check response")
+			}
+
+			msg = msg[n+int(size):]
+		default:
+			return 0, errors.New("malformed health check response")
+		}
+	}
+
+	return status, nil
+}
@@ -0,0 +1,92 @@
+package grpc
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newHealthServer serves grpc.health.v1.Health/Check with statuses by service name
+func newHealthServer(statuses map[string]byte) *httptest.Server {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		var service string
+
+		if len(body) > 7 {
+			service = string(body[7:])
+		}
+
+		status, found := statuses[service]
+
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+
+		if !found {
+			w.Header().Set("Grpc-Status", "5")
+			w.Header().Set("Grpc-Message", "unknown%20service")
+			return
+		}
+
+		frame := make([]byte, 5, 7)
+		binary.BigEndian.PutUint32(frame[1:], 2)
+
+		_, _ = w.Write(append(frame, 0x08, status))
+
+		w.Header().Set("Grpc-Status", "0")
+	}))
+
+	srv.Config.Protocols = new(http.Protocols)
+	srv.Config.Protocols.SetUnencryptedHTTP2(true)
+	srv.Start()
+
+	return srv
+}
+
+func TestGRPC_Test(t *testing.T) {
+	srv := newHealthServer(map[string]byte{"": 1, "orders": 2})
+	defer srv.Close()
+
+	addr := srv.Listener.Addr().String()
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"grpc://" + addr, true},
+		{"grpc://" + addr + "?service=orders", false},
+		{"grpc://" + addr + "?service=missing", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestDecodeResponse(t *testing.T) {
+	status, err := decodeResponse([]byte{0, 0, 0, 0, 2, 0x08, 0x01})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), status)
+
+	_, err = decodeResponse([]byte{0, 0, 0, 0, 5, 0x08})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,108 @@
+// Package host provides a resource testing name resolution and TCP reachability of a host
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/dnsconfig"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+const (
+	Scheme = "host"
+
+	DefaultTimeout = 5 * time.Second
+)
+
+var (
+	// ErrResolve is wrapped by failures of the name resolution phase
+	ErrResolve = errors.New("dns")
+	// ErrConnect is wrapped by failures of the connection phase
+	ErrConnect = errors.New("tcp")
+)
+
+// Host resolves a host name and dials the resolved addresses until one accepts a connection,
+// e.g. host://db.internal:5432. Errors tell whether the name does not resolve or the port is closed.
+// Names are resolved with the system resolver or a given name server, e.g. ?nameserver=10.0.0.2:53.
+type Host struct {
+	host     string
+	port     string
+	resolver *net.Resolver
+	timeout  time.Duration
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Hostname() == "" || u.Port() == "" {
+		return nil, fmt.Errorf("%q: host and port are required: %w", u.Host, waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	h := &Host{
+		host:     u.Hostname(),
+		port:     u.Port(),
+		resolver: dnsconfig.FromQuery(q),
+	}
+
+	var err error
+
+	if h.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+func (h *Host) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	addrs, err := h.resolver.LookupHost(ctx, h.host)
+
+	if err != nil {
+		return fmt.Errorf("%w: %s does not resolve: %s", ErrResolve, h.host, unwrapDNSError(err))
+	}
+
+	var errs []error
+
+	for _, addr := range addrs {
+		var d net.Dialer
+
+		conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(addr, h.port))
+
+		if err == nil {
+			return conn.Close()
+		}
+
+		errs = append(errs, err)
+	}
+
+	return fmt.Errorf("%w: port %s is not reachable on %s: %w", ErrConnect, h.port, h.host, errors.Join(errs...))
+}
+
+// unwrapDNSError drops the repeated host name from resolver errors
+func unwrapDNSError(err error) string {
+	var dnsErr *net.DNSError
+
+	if errors.As(err, &dnsErr) {
+		return dnsErr.Err
+	}
+
+	return err.Error()
+}
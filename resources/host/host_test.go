@@ -0,0 +1,62 @@
+package host
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHost_Test(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	defer l.Close()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	_, open, _ := net.SplitHostPort(l.Addr().String())
+	_, port, _ := net.SplitHostPort(closed.Addr().String())
+	closed.Close()
+
+	cases := []struct {
+		location string
+		err      error
+	}{
+		{"host://127.0.0.1:" + open, nil},
+		{"host://localhost:" + open, nil},
+		{"host://127.0.0.1:" + port, ErrConnect},
+		{"host://waitfor.invalid:" + open + "?timeout=1s", ErrResolve},
+		{"host://db.example.com:" + open + "?timeout=1s&nameserver=127.0.0.1:" + port, ErrResolve},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.err == nil {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.ErrorIs(t, err, c.err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"host://localhost", "host://:5432", "host://localhost:5432?timeout=x"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
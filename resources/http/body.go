@@ -0,0 +1,92 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+// bodyMatcher validates a response body
+type bodyMatcher struct {
+	contains string
+	regex    *regexp.Regexp
+	jsonPath jsonPath
+	equals   *string
+}
+
+func newBodyMatcher(q url.Values) (*bodyMatcher, error) {
+	m := &bodyMatcher{
+		contains: q.Get("contains"),
+	}
+
+	if value := q.Get("regex"); value != "" {
+		re, err := regexp.Compile(value)
+
+		if err != nil {
+			return nil, fmt.Errorf("%q: %s: %w", "regex", err, waitfor.ErrInvalidArgument)
+		}
+
+		m.regex = re
+	}
+
+	if value := q.Get("jsonpath"); value != "" {
+		path, err := parseJSONPath(value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		m.jsonPath = path
+	}
+
+	if q.Has("equals") {
+		if m.jsonPath == nil {
+			return nil, fmt.Errorf("%q: requires jsonpath: %w", "equals", waitfor.ErrInvalidArgument)
+		}
+
+		equals := q.Get("equals")
+		m.equals = &equals
+	}
+
+	if m.contains == "" && m.regex == nil && m.jsonPath == nil {
+		return nil, nil
+	}
+
+	return m, nil
+}
+
+func (m *bodyMatcher) Match(body []byte) error {
+	if m.contains != "" && !bytes.Contains(body, []byte(m.contains)) {
+		return fmt.Errorf("response body does not contain %q", m.contains)
+	}
+
+	if m.regex != nil && !m.regex.Match(body) {
+		return fmt.Errorf("response body does not match %q", m.regex)
+	}
+
+	if m.jsonPath == nil {
+		return nil
+	}
+
+	var doc interface{}
+
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return fmt.Errorf("response body is not valid json: %w", err)
+	}
+
+	value, found := m.jsonPath.Lookup(doc)
+
+	if !found {
+		return fmt.Errorf("response body has no value at the json path")
+	}
+
+	if m.equals != nil && formatJSONValue(value) != *m.equals {
+		return fmt.Errorf("unexpected json value: %q", formatJSONValue(value))
+	}
+
+	return nil
+}
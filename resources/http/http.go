@@ -0,0 +1,235 @@
+// Package http provides a resource requesting an HTTP(S) endpoint
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	nethttp "net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"github.com/go-waitfor/waitfor/internal/tlsconfig"
+)
+
+const (
+	Scheme       = "http"
+	SecureScheme = "https"
+	UnixScheme   = "http+unix"
+
+	DefaultTimeout      = 5 * time.Second
+	DefaultMaxRedirects = 10
+
+	// maxBodySize limits the amount of response body read for matching
+	maxBodySize = 1024 * 1024
+)
+
+// params are resource options, they are removed from the request URL
+var params = append(
+	[]string{"method", "status", "redirects", "timeout", "contains", "regex", "jsonpath", "equals", "header", "bearer", "socket"},
+	tlsconfig.Params...,
+)
+
+// HTTP requests an endpoint and checks the response status and optionally its body,
+// e.g. http://localhost:8080/health?status=2xx,301&method=HEAD&redirects=0
+// or http://localhost:8080/health?jsonpath=$.status&equals=UP.
+// Without the status option any response below 400 is accepted.
+// Credentials are sent from the URL userinfo as basic auth, from the bearer option as a token,
+// and custom headers are set by repeated header options, e.g. ?header=X-Api-Key:secret.
+// HTTPS endpoints accept TLS options, e.g. ?ca=/etc/ssl/private-ca.pem or ?insecure=true.
+// Servers listening on a Unix domain socket are requested with the socket option,
+// e.g. http+unix://localhost/_ping?socket=/var/run/docker.sock, the host is sent as is and defaults to localhost.
+type HTTP struct {
+	url    *url.URL
+	method string
+	header nethttp.Header
+	status statusCodes
+	body   *bodyMatcher
+	client *nethttp.Client
+}
+
+// Use returns the resource config, options are applied to every resource
+func Use(setters ...Option) waitfor.ResourceConfig {
+	opts := newOptions(setters)
+
+	return waitfor.ResourceConfig{
+		Scheme: []string{Scheme, SecureScheme, UnixScheme},
+		Factory: func(u *url.URL) (waitfor.Resource, error) {
+			return newHTTP(u, opts)
+		},
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	return newHTTP(u, newOptions(nil))
+}
+
+func newHTTP(u *url.URL, opts *options) (*HTTP, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	timeout, err := query.Duration(q, "timeout", DefaultTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	redirects, err := query.Int(q, "redirects", DefaultMaxRedirects)
+
+	if err != nil {
+		return nil, err
+	}
+
+	status := statusCodes{{100, 399}}
+
+	if value := q.Get("status"); value != "" {
+		if status, err = parseStatusCodes(value); err != nil {
+			return nil, err
+		}
+	}
+
+	body, err := newBodyMatcher(q)
+
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := newHeader(q, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := tlsconfig.FromQuery(q, opts.tls)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transport := nethttp.DefaultTransport.(*nethttp.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	target := stripParams(u)
+
+	if u.Scheme == UnixScheme {
+		socket := q.Get("socket")
+
+		if socket == "" {
+			return nil, fmt.Errorf("%q: missing socket path: %w", "socket", waitfor.ErrInvalidArgument)
+		}
+
+		transport.Proxy = nil
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+
+			return d.DialContext(ctx, "unix", socket)
+		}
+
+		target.Scheme = Scheme
+
+		if target.Host == "" {
+			target.Host = "localhost"
+		}
+	}
+
+	return &HTTP{
+		url:    target,
+		method: strings.ToUpper(query.String(q, "method", nethttp.MethodGet)),
+		header: header,
+		status: status,
+		body:   body,
+		client: &nethttp.Client{
+			Transport:     transport,
+			Timeout:       timeout,
+			CheckRedirect: checkRedirect(redirects),
+		},
+	}, nil
+}
+
+func (h *HTTP) Test(ctx context.Context) error {
+	req, err := nethttp.NewRequestWithContext(ctx, h.method, h.url.String(), nil)
+
+	if err != nil {
+		return err
+	}
+
+	for key, values := range h.header {
+		req.Header[key] = values
+	}
+
+	res, err := h.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxBodySize))
+
+	if err != nil {
+		return err
+	}
+
+	if !h.status.Match(res.StatusCode) {
+		return fmt.Errorf("unexpected status: %s", res.Status)
+	}
+
+	if h.body != nil {
+		return h.body.Match(body)
+	}
+
+	return nil
+}
+
+// newHeader merges configured headers with the ones set by url options
+func newHeader(q url.Values, opts *options) (nethttp.Header, error) {
+	header := opts.header.Clone()
+
+	for _, value := range q["header"] {
+		key, value, found := strings.Cut(value, ":")
+
+		if !found || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("%q: %q: %w", "header", key, waitfor.ErrInvalidArgument)
+		}
+
+		header.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+
+	if token := q.Get("bearer"); token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+
+	return header, nil
+}
+
+// checkRedirect limits the number of followed redirects, the last redirect response is returned as is
+func checkRedirect(max int) func(req *nethttp.Request, via []*nethttp.Request) error {
+	return func(_ *nethttp.Request, via []*nethttp.Request) error {
+		if len(via) > max {
+			return nethttp.ErrUseLastResponse
+		}
+
+		return nil
+	}
+}
+
+// stripParams returns a copy of the url without resource options
+func stripParams(u *url.URL) *url.URL {
+	out := *u
+	q := out.Query()
+
+	for _, p := range params {
+		q.Del(p)
+	}
+
+	out.RawQuery = q.Encode()
+
+	return &out
+}
@@ -0,0 +1,224 @@
+package http
+
+import (
+	"context"
+	"net"
+	nethttp "net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer() *httptest.Server {
+	return httptest.NewServer(newHandler())
+}
+
+func newHandler() nethttp.Handler {
+	mux := nethttp.NewServeMux()
+
+	mux.HandleFunc("/ok", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.URL.Query().Get("status") != "" {
+			w.WriteHeader(nethttp.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(nethttp.StatusNoContent)
+	})
+
+	mux.HandleFunc("/unavailable", func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusServiceUnavailable)
+	})
+
+	mux.HandleFunc("/redirect", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		nethttp.Redirect(w, r, "/ok", nethttp.StatusFound)
+	})
+
+	return mux
+}
+
+func TestHTTP_Test(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+
+	cases := []struct {
+		path string
+		ok   bool
+	}{
+		{"/ok", true},
+		{"/ok?status=204", true},
+		{"/ok?status=200", false},
+		{"/ok?status=2xx&method=head", true},
+		{"/unavailable", false},
+		{"/unavailable?status=503", true},
+		{"/redirect", true},
+		{"/redirect?redirects=0", true},
+		{"/redirect?redirects=0&status=2xx", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(srv.URL + c.path)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.path)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.path)
+		} else {
+			assert.Error(t, err, c.path)
+		}
+	}
+}
+
+func TestNew_InvalidStatus(t *testing.T) {
+	for _, status := range []string{"abc", "6xx", "99", "2x"} {
+		u, _ := url.Parse("http://localhost/?status=" + status)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, status)
+	}
+}
+
+func TestHTTP_Test_Body(t *testing.T) {
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		_, _ = w.Write([]byte(`{"status":"UP","checks":[{"name":"db","up":true}],"version":2}`))
+	}))
+	defer srv.Close()
+
+	cases := []struct {
+		query string
+		ok    bool
+	}{
+		{"contains=UP", true},
+		{"contains=DOWN", false},
+		{"regex=version.%3A2", true},
+		{"regex=^starting", false},
+		{"jsonpath=$.status&equals=UP", true},
+		{"jsonpath=$.status&equals=DOWN", false},
+		{"jsonpath=$.checks[0].up&equals=true", true},
+		{"jsonpath=$['version']&equals=2", true},
+		{"jsonpath=$.checks[1]", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(srv.URL + "?" + c.query)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.query)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.query)
+		} else {
+			assert.Error(t, err, c.query)
+		}
+	}
+}
+
+func TestHTTP_Test_Auth(t *testing.T) {
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		user, password, _ := r.BasicAuth()
+
+		switch {
+		case user == "user" && password == "secret":
+		case r.Header.Get("Authorization") == "Bearer token":
+		case r.Header.Get("X-Api-Key") == "key":
+		default:
+			w.WriteHeader(nethttp.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+
+	for _, location := range []string{
+		"http://user:secret@" + u.Host,
+		srv.URL + "?bearer=token",
+		srv.URL + "?header=X-Api-Key:key",
+	} {
+		u, _ := url.Parse(location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, location)
+		assert.NoError(t, rsc.Test(context.Background()), location)
+	}
+
+	rsc, _ := New(u)
+
+	assert.Error(t, rsc.Test(context.Background()))
+
+	rsc, _ = Use(WithBearerToken("token")).Factory(u)
+
+	assert.NoError(t, rsc.Test(context.Background()))
+}
+
+func TestHTTP_Test_TLS(t *testing.T) {
+	srv := httptest.NewTLSServer(nethttp.HandlerFunc(func(_ nethttp.ResponseWriter, _ *nethttp.Request) {}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+	rsc, err := New(u)
+
+	assert.NoError(t, err)
+	assert.Error(t, rsc.Test(context.Background()))
+
+	u, _ = url.Parse(srv.URL + "?insecure=true")
+	rsc, err = New(u)
+
+	assert.NoError(t, err)
+	assert.NoError(t, rsc.Test(context.Background()))
+
+	u, _ = url.Parse(srv.URL)
+	rsc, err = Use(WithTLSConfig(srv.Client().Transport.(*nethttp.Transport).TLSClientConfig)).Factory(u)
+
+	assert.NoError(t, err)
+	assert.NoError(t, rsc.Test(context.Background()))
+}
+
+func TestHTTP_Test_Unix(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "api.sock")
+	l, err := net.Listen("unix", socket)
+
+	assert.NoError(t, err)
+
+	server := &nethttp.Server{Handler: newHandler()}
+	defer server.Close()
+
+	go func() { _ = server.Serve(l) }()
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"http+unix://localhost/ok?socket=" + socket, true},
+		{"http+unix:///ok?status=204&socket=" + socket, true},
+		{"http+unix://localhost/unavailable?socket=" + socket, false},
+		{"http+unix://localhost/ok?socket=" + socket + ".missing", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := Use().Factory(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+
+	u, _ := url.Parse("http+unix://localhost/ok")
+	_, err = New(u)
+
+	assert.ErrorIs(t, err, waitfor.ErrInvalidArgument)
+}
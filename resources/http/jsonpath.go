@@ -0,0 +1,111 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+// jsonPath is a simple JSONPath subset with member and index access, e.g. $.status or $.checks[0].name
+type jsonPath []interface{}
+
+func parseJSONPath(value string) (jsonPath, error) {
+	invalid := fmt.Errorf("%q: %q: %w", "jsonpath", value, waitfor.ErrInvalidArgument)
+	path := make(jsonPath, 0, 4)
+	s := strings.TrimPrefix(value, "$")
+
+	for s != "" {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			end := strings.IndexAny(s, ".[")
+
+			if end < 0 {
+				end = len(s)
+			}
+
+			if end == 0 {
+				return nil, invalid
+			}
+
+			path = append(path, s[:end])
+			s = s[end:]
+		case '[':
+			end := strings.IndexByte(s, ']')
+
+			if end < 0 {
+				return nil, invalid
+			}
+
+			key := s[1:end]
+			s = s[end+1:]
+
+			if unquoted, err := strconv.Unquote(strings.ReplaceAll(key, "'", "\"")); err == nil {
+				path = append(path, unquoted)
+				continue
+			}
+
+			index, err := strconv.Atoi(key)
+
+			if err != nil {
+				return nil, invalid
+			}
+
+			path = append(path, index)
+		default:
+			return nil, invalid
+		}
+	}
+
+	return path, nil
+}
+
+// Lookup returns a value at the path
+func (p jsonPath) Lookup(doc interface{}) (interface{}, bool) {
+	current := doc
+
+	for _, segment := range p {
+		switch key := segment.(type) {
+		case string:
+			obj, ok := current.(map[string]interface{})
+
+			if !ok {
+				return nil, false
+			}
+
+			if current, ok = obj[key]; !ok {
+				return nil, false
+			}
+		case int:
+			arr, ok := current.([]interface{})
+
+			if !ok || key < 0 || key >= len(arr) {
+				return nil, false
+			}
+
+			current = arr[key]
+		}
+	}
+
+	return current, true
+}
+
+// formatJSONValue formats a decoded json value for comparison
+func formatJSONValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return "null"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		out, _ := json.Marshal(v)
+		return string(out)
+	}
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"crypto/tls"
+	nethttp "net/http"
+)
+
+type (
+	options struct {
+		header nethttp.Header
+		tls    *tls.Config
+	}
+
+	Option func(opts *options)
+)
+
+func newOptions(setters []Option) *options {
+	opts := &options{
+		header: make(nethttp.Header),
+	}
+
+	for _, setter := range setters {
+		setter(opts)
+	}
+
+	return opts
+}
+
+// Set a custom request header
+func WithHeader(key, value string) Option {
+	return func(opts *options) {
+		opts.header.Add(key, value)
+	}
+}
+
+// Set a bearer token
+func WithBearerToken(token string) Option {
+	return WithHeader("Authorization", "Bearer "+token)
+}
+
+// Set basic auth credentials
+func WithBasicAuth(username, password string) Option {
+	return func(opts *options) {
+		req := nethttp.Request{Header: make(nethttp.Header)}
+		req.SetBasicAuth(username, password)
+
+		opts.header.Set("Authorization", req.Header.Get("Authorization"))
+	}
+}
+
+// Set a custom TLS config, url options override it per resource
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(opts *options) {
+		opts.tls = cfg
+	}
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+type (
+	statusRange struct {
+		min int
+		max int
+	}
+
+	// statusCodes is a list of expected response status codes
+	statusCodes []statusRange
+)
+
+// parseStatusCodes parses a list like "200,204" or "2xx,301"
+func parseStatusCodes(value string) (statusCodes, error) {
+	codes := make(statusCodes, 0, 2)
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+
+		if len(item) == 3 && strings.HasSuffix(item, "xx") {
+			class, err := strconv.Atoi(item[:1])
+
+			if err != nil || class < 1 || class > 5 {
+				return nil, fmt.Errorf("%q: %q: %w", "status", item, waitfor.ErrInvalidArgument)
+			}
+
+			codes = append(codes, statusRange{class * 100, class*100 + 99})
+
+			continue
+		}
+
+		code, err := strconv.Atoi(item)
+
+		if err != nil || code < 100 || code > 599 {
+			return nil, fmt.Errorf("%q: %q: %w", "status", item, waitfor.ErrInvalidArgument)
+		}
+
+		codes = append(codes, statusRange{code, code})
+	}
+
+	return codes, nil
+}
+
+func (s statusCodes) Match(code int) bool {
+	for _, r := range s {
+		if code >= r.min && code <= r.max {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,105 @@
+package influxdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfluxDB_Test(t *testing.T) {
+	// the fake server knows the acme organization with the metrics bucket and the telegraf database
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		user, password, _ := r.BasicAuth()
+		authorized := r.Header.Get("Authorization") == "Token secret" || (user == "admin" && password == "secret")
+
+		switch {
+		case r.URL.Path == "/health":
+			_, _ = w.Write([]byte(`{"name":"influxdb","status":"pass"}`))
+		case r.URL.Path == "/ping":
+			w.WriteHeader(http.StatusNoContent)
+		case !authorized:
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/api/v2/orgs" && q.Get("org") == "acme":
+			_, _ = w.Write([]byte(`{"orgs":[{"name":"acme"}]}`))
+		case r.URL.Path == "/api/v2/orgs":
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == "/api/v2/buckets" && q.Get("org") == "acme" && q.Get("name") == "metrics":
+			_, _ = w.Write([]byte(`{"buckets":[{"name":"metrics"}]}`))
+		case r.URL.Path == "/api/v2/buckets":
+			_, _ = w.Write([]byte(`{"buckets":[]}`))
+		case r.URL.Path == "/query" && q.Get("q") == "SHOW DATABASES":
+			_, _ = w.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"databases","columns":["name"],"values":[["_internal"],["telegraf"]]}]}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	host := srv.Listener.Addr().String()
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"influxdb://" + host, true},
+		{"influxdb://:secret@" + host + "?org=acme", true},
+		{"influxdb://secret@" + host + "?org=acme&bucket=metrics", true},
+		{"influxdb://:secret@" + host + "?org=acme&bucket=logs", false},
+		{"influxdb://:secret@" + host + "?org=globex", false},
+		{"influxdb://:wrong@" + host + "?org=acme", false},
+		{"influxdb://" + host + "?version=1", true},
+		{"influxdb://admin:secret@" + host + "?version=1&db=telegraf", true},
+		{"influxdb://admin:secret@" + host + "?version=1&db=metrics", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestInfluxDB_Test_NotReady(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"name":"influxdb","status":"fail","message":"not ready"}`))
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse("influxdb://" + srv.Listener.Addr().String())
+	rsc, err := New(u)
+
+	assert.NoError(t, err)
+	assert.ErrorContains(t, rsc.Test(context.Background()), "not ready")
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{
+		"influxdb://localhost?version=3",
+		"influxdb://localhost?version=1&org=acme",
+		"influxdb://localhost?db=metrics",
+		"influxdb://localhost?bucket=metrics",
+		"influxdb://localhost?tls=maybe",
+		"influxdb://localhost?timeout=x",
+	} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
@@ -0,0 +1,185 @@
+// Package jwks provides a resource checking JSON Web Key Sets
+package jwks
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"github.com/go-waitfor/waitfor/internal/tlsconfig"
+)
+
+const (
+	Scheme = "jwks"
+
+	DefaultTimeout = 5 * time.Second
+)
+
+type (
+	// JWKS waits for a key set to contain at least one usable signing key, or a key with a given id,
+	// e.g. jwks://keycloak:8443/realms/main/protocol/openid-connect/certs?kid=main-2024.
+	// Key sets served without TLS are checked with tls=false.
+	JWKS struct {
+		url     *url.URL
+		kid     string
+		timeout time.Duration
+		client  *http.Client
+	}
+
+	key struct {
+		Kid    string   `json:"kid"`
+		Kty    string   `json:"kty"`
+		Use    string   `json:"use"`
+		KeyOps []string `json:"key_ops"`
+		Crv    string   `json:"crv"`
+		N      string   `json:"n"`
+		E      string   `json:"e"`
+		X      string   `json:"x"`
+		Y      string   `json:"y"`
+	}
+)
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("%q: missing host: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	j := &JWKS{
+		url: &url.URL{
+			Scheme: "https",
+			Host:   u.Host,
+			Path:   u.Path,
+		},
+		kid: q.Get("kid"),
+	}
+
+	secure, err := query.Bool(q, "tls", true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !secure {
+		j.url.Scheme = "http"
+	}
+
+	if j.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if transport.TLSClientConfig, err = tlsconfig.FromQuery(q, &tls.Config{}); err != nil {
+		return nil, err
+	}
+
+	j.client = &http.Client{Transport: transport}
+
+	return j, nil
+}
+
+func (j *JWKS) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, j.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.url.String(), nil)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := j.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("key set: unexpected status code %d", res.StatusCode)
+	}
+
+	var set struct {
+		Keys []key `json:"keys"`
+	}
+
+	if err := json.NewDecoder(io.LimitReader(res.Body, 1024*1024)).Decode(&set); err != nil {
+		return fmt.Errorf("key set: %w", err)
+	}
+
+	for _, k := range set.Keys {
+		if (j.kid == "" || k.Kid == j.kid) && k.usable() {
+			return nil
+		}
+	}
+
+	if j.kid != "" {
+		return fmt.Errorf("key set has no usable signing key %q", j.kid)
+	}
+
+	return fmt.Errorf("key set has no usable signing keys out of %d", len(set.Keys))
+}
+
+// usable reports whether a key can verify signatures and has complete key material
+func (k *key) usable() bool {
+	if k.Use != "" && k.Use != "sig" {
+		return false
+	}
+
+	if len(k.KeyOps) > 0 && !contains(k.KeyOps, "verify") {
+		return false
+	}
+
+	switch k.Kty {
+	case "RSA":
+		return decodes(k.N, k.E)
+	case "EC":
+		return k.Crv != "" && decodes(k.X, k.Y)
+	case "OKP":
+		return k.Crv != "" && decodes(k.X)
+	}
+
+	return false
+}
+
+// decodes reports whether all values are non-empty base64url encoded
+func decodes(values ...string) bool {
+	for _, v := range values {
+		if b, err := base64.RawURLEncoding.DecodeString(v); err != nil || len(b) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
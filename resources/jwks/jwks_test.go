@@ -0,0 +1,73 @@
+package jwks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWKS_Test(t *testing.T) {
+	sets := map[string]string{
+		"/certs":      `{"keys":[{"kid":"enc","kty":"RSA","use":"enc","n":"sXchDaQebHnPiGvyDOAT4saGEUetSyo9MKLOoWFsueri","e":"AQAB"},{"kid":"main","kty":"EC","crv":"P-256","x":"f83OJ3D2xF1Bg8vub9tLe1gHMzV76e8Tus9uPHvRVEU","y":"x_FEzRu9m36HLN_tue659LNpXW6pCyStikYjKIWI5a0"}]}`,
+		"/empty":      `{"keys":[]}`,
+		"/encryption": `{"keys":[{"kid":"enc","kty":"RSA","use":"enc","n":"sXchDaQebHnPiGvyDOAT4saGEUetSyo9MKLOoWFsueri","e":"AQAB"}]}`,
+		"/broken":     `{"keys":[{"kid":"ed","kty":"OKP","crv":"Ed25519","x":"not base64!"}]}`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		set, ok := sets[r.URL.Path]
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(set))
+	}))
+	defer srv.Close()
+
+	host := srv.Listener.Addr().String()
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"jwks://" + host + "/certs?tls=false", true},
+		{"jwks://" + host + "/certs?tls=false&kid=main", true},
+		{"jwks://" + host + "/certs?tls=false&kid=enc", false},
+		{"jwks://" + host + "/certs?tls=false&kid=next", false},
+		{"jwks://" + host + "/empty?tls=false", false},
+		{"jwks://" + host + "/encryption?tls=false", false},
+		{"jwks://" + host + "/broken?tls=false", false},
+		{"jwks://" + host + "/missing?tls=false", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"jwks:///certs", "jwks://id/certs?tls=maybe", "jwks://id/certs?timeout=x"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
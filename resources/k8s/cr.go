@@ -0,0 +1,101 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/kubeclient"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+// CustomResource waits for an object of any group, version and resource to have a condition set to True,
+// e.g. k8s-cr://web-tls?group=cert-manager.io&version=v1&resource=certificates&condition=Ready.
+// The condition defaults to Ready, cluster scoped objects are checked with cluster=true.
+type CustomResource struct {
+	name      string
+	path      string
+	condition string
+	client    *kubeclient.Client
+	timeout   time.Duration
+}
+
+func newCustomResource(u *url.URL) (*CustomResource, error) {
+	q := u.Query()
+	version, resource := q.Get("version"), q.Get("resource")
+
+	if version == "" {
+		return nil, fmt.Errorf("%q: missing value: %w", "version", waitfor.ErrInvalidArgument)
+	}
+
+	if resource == "" {
+		return nil, fmt.Errorf("%q: missing value: %w", "resource", waitfor.ErrInvalidArgument)
+	}
+
+	cluster, err := query.Bool(q, "cluster", false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// objects of the core group are served under /api
+	prefix := path.Join("/apis", q.Get("group"), version)
+
+	if q.Get("group") == "" {
+		prefix = path.Join("/api", version)
+	}
+
+	if !cluster {
+		prefix = path.Join(prefix, "namespaces", kubeclient.Namespace(q))
+	}
+
+	c := &CustomResource{
+		name:      resource + "/" + u.Host,
+		path:      path.Join(prefix, resource, u.Host),
+		condition: query.String(q, "condition", "Ready"),
+	}
+
+	if c.client, err = kubeclient.FromQuery(q); err != nil {
+		return nil, err
+	}
+
+	if c.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *CustomResource) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var object struct {
+		Status struct {
+			Conditions []condition `json:"conditions"`
+		} `json:"status"`
+	}
+
+	if err := c.client.Get(ctx, c.path, &object); err != nil {
+		return err
+	}
+
+	cond := findCondition(object.Status.Conditions, c.condition)
+
+	if cond == nil {
+		return fmt.Errorf("%s has no %s condition", c.name, c.condition)
+	}
+
+	if cond.Status != "True" {
+		if reason := cond.describe(); reason != "" {
+			return fmt.Errorf("%s is not %s: %s", c.name, c.condition, reason)
+		}
+
+		return fmt.Errorf("%s is not %s", c.name, c.condition)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package k8s
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomResource_Test(t *testing.T) {
+	objects := map[string]string{
+		"/apis/cert-manager.io/v1/namespaces/apps/certificates/web-tls": `{"status":{"conditions":[{"type":"Ready","status":"True"}]}}`,
+		"/apis/cert-manager.io/v1/namespaces/apps/certificates/api-tls": `{"status":{"conditions":[{"type":"Ready","status":"False","reason":"Issuing"}]}}`,
+		"/apis/example.org/v1/clusterdatabases/main":                    `{"status":{"conditions":[{"type":"Synced","status":"True"},{"type":"Ready","status":"Unknown"}]}}`,
+		"/api/v1/namespaces/apps/pods/web":                              `{"status":{"conditions":[{"type":"Ready","status":"True"}]}}`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		object, ok := objects[r.URL.Path]
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(object))
+	}))
+	defer srv.Close()
+
+	certificates := "?group=cert-manager.io&version=v1&resource=certificates&namespace=apps&endpoint=" + url.QueryEscape(srv.URL)
+	databases := "?group=example.org&version=v1&resource=clusterdatabases&cluster=true&endpoint=" + url.QueryEscape(srv.URL)
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"k8s-cr://web-tls" + certificates, true},
+		{"k8s-cr://api-tls" + certificates, false},
+		{"k8s-cr://db-tls" + certificates, false},
+		{"k8s-cr://web-tls" + certificates + "&condition=Issuing", false},
+		{"k8s-cr://main" + databases + "&condition=Synced", true},
+		{"k8s-cr://main" + databases, false},
+		{"k8s-cr://web?version=v1&resource=pods&namespace=apps&endpoint=" + url.QueryEscape(srv.URL), true},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/kubeclient"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+// Job waits for a job to complete successfully and stops waiting once it has failed,
+// e.g. k8s-job://migrate?namespace=apps. The API server is reached with the pod service account
+// or through kubectl proxy, e.g. k8s-job://migrate?endpoint=http://127.0.0.1:8001
+type Job struct {
+	name    string
+	path    string
+	client  *kubeclient.Client
+	timeout time.Duration
+}
+
+func newJob(u *url.URL) (*Job, error) {
+	q := u.Query()
+	j := &Job{
+		name: u.Host,
+		path: path.Join("/apis/batch/v1/namespaces", kubeclient.Namespace(q), "jobs", u.Host),
+	}
+
+	var err error
+
+	if j.client, err = kubeclient.FromQuery(q); err != nil {
+		return nil, err
+	}
+
+	if j.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
+
+func (j *Job) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, j.timeout)
+	defer cancel()
+
+	var job struct {
+		Status struct {
+			Active     int         `json:"active"`
+			Conditions []condition `json:"conditions"`
+		} `json:"status"`
+	}
+
+	if err := j.client.Get(ctx, j.path, &job); err != nil {
+		return err
+	}
+
+	if c := findCondition(job.Status.Conditions, "Failed"); c != nil && c.Status == "True" {
+		return fmt.Errorf("job %s failed: %s: %w", j.name, c.describe(), waitfor.ErrPermanent)
+	}
+
+	if c := findCondition(job.Status.Conditions, "Complete"); c != nil && c.Status == "True" {
+		return nil
+	}
+
+	return fmt.Errorf("job %s is not complete, %d pods active", j.name, job.Status.Active)
+}
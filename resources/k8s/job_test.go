@@ -0,0 +1,64 @@
+package k8s
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJob_Test(t *testing.T) {
+	jobs := map[string]string{
+		"/apis/batch/v1/namespaces/apps/jobs/migrate": `{"status":{"conditions":[{"type":"Complete","status":"True"}]}}`,
+		"/apis/batch/v1/namespaces/apps/jobs/seed":    `{"status":{"conditions":[{"type":"Failed","status":"True","reason":"BackoffLimitExceeded"}]}}`,
+		"/apis/batch/v1/namespaces/apps/jobs/import":  `{"status":{"active":1}}`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		job, ok := jobs[r.URL.Path]
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(job))
+	}))
+	defer srv.Close()
+
+	endpoint := "&endpoint=" + url.QueryEscape(srv.URL)
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"k8s-job://migrate?namespace=apps" + endpoint, true},
+		{"k8s-job://seed?namespace=apps" + endpoint, false},
+		{"k8s-job://import?namespace=apps" + endpoint, false},
+		{"k8s-job://migrate?namespace=web" + endpoint, false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+
+	u, _ := url.Parse("k8s-job://seed?namespace=apps" + endpoint)
+	rsc, _ := New(u)
+
+	assert.ErrorIs(t, rsc.Test(context.Background()), waitfor.ErrPermanent)
+}
@@ -0,0 +1,74 @@
+// Package k8s provides resources checking Kubernetes objects through the API server
+package k8s
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+const (
+	JobScheme            = "k8s-job"
+	CustomResourceScheme = "k8s-cr"
+
+	DefaultTimeout = 5 * time.Second
+)
+
+type condition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{JobScheme, CustomResourceScheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Host == "" || strings.Trim(u.Path, "/") != "" {
+		return nil, fmt.Errorf("%q: host must be an object name: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	switch u.Scheme {
+	case JobScheme:
+		return newJob(u)
+	case CustomResourceScheme:
+		return newCustomResource(u)
+	}
+
+	return nil, fmt.Errorf("%q: unknown scheme %q: %w", "url", u.Scheme, waitfor.ErrInvalidArgument)
+}
+
+// findCondition returns a condition of a given type or nil
+func findCondition(conditions []condition, kind string) *condition {
+	for i := range conditions {
+		if conditions[i].Type == kind {
+			return &conditions[i]
+		}
+	}
+
+	return nil
+}
+
+// describe explains why a condition is set
+func (c *condition) describe() string {
+	switch {
+	case c.Reason != "" && c.Message != "":
+		return c.Reason + ": " + c.Message
+	case c.Message != "":
+		return c.Message
+	}
+
+	return c.Reason
+}
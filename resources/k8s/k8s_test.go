@@ -0,0 +1,20 @@
+package k8s
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_InvalidArgument(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+
+	for _, location := range []string{"k8s-job://", "k8s-job://migrate/extra", "k8s-job://migrate", "k8s-job://migrate?endpoint=localhost", "k8s-job://migrate?endpoint=http://localhost:8001&timeout=x", "k8s-pod://web", "k8s-cr://web-tls?resource=certificates", "k8s-cr://web-tls?version=v1", "k8s-cr://web-tls?version=v1&resource=certificates&cluster=maybe"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
@@ -0,0 +1,101 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"github.com/segmentio/kafka-go"
+)
+
+// Topic waits for a topic to exist with leaders elected for all its partitions and optionally
+// with a given number of partitions, e.g. kafka-topic://broker1:9092,broker2:9092/orders?partitions=12.
+// Connection options are the same as for Kafka. Metadata requests never create topics.
+type Topic struct {
+	*Kafka
+	topic      string
+	partitions int
+}
+
+func newTopic(u *url.URL, k *Kafka) (*Topic, error) {
+	t := &Topic{
+		Kafka: k,
+		topic: strings.Trim(u.Path, "/"),
+	}
+
+	if t.topic == "" || strings.Contains(t.topic, "/") {
+		return nil, fmt.Errorf("%q: path must be a topic name: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	var err error
+
+	if t.partitions, err = query.Int(u.Query(), "partitions", 0); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *Topic) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	var errs []error
+
+	for _, broker := range t.brokers {
+		err := t.testTopic(ctx, broker)
+
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", broker, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (t *Topic) testTopic(ctx context.Context, broker string) error {
+	transport := &kafka.Transport{
+		TLS:  t.dialer.TLS,
+		SASL: t.dialer.SASLMechanism,
+	}
+	defer transport.CloseIdleConnections()
+
+	client := &kafka.Client{Addr: kafka.TCP(broker), Transport: transport}
+	res, err := client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{t.topic}})
+
+	if err != nil {
+		return err
+	}
+
+	if len(res.Topics) == 0 {
+		return fmt.Errorf("topic %s does not exist", t.topic)
+	}
+
+	topic := res.Topics[0]
+
+	if topic.Error != nil {
+		return fmt.Errorf("topic %s: %w", t.topic, topic.Error)
+	}
+
+	if t.partitions > 0 && len(topic.Partitions) != t.partitions {
+		return fmt.Errorf("topic %s has %d partitions, expected %d", t.topic, len(topic.Partitions), t.partitions)
+	}
+
+	for _, p := range topic.Partitions {
+		if p.Error != nil {
+			return fmt.Errorf("topic %s partition %d: %w", t.topic, p.ID, p.Error)
+		}
+
+		if p.Leader.Host == "" {
+			return fmt.Errorf("topic %s partition %d has no leader", t.topic, p.ID)
+		}
+	}
+
+	return nil
+}
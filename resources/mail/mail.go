@@ -0,0 +1,215 @@
+// Package mail provides resources checking IMAP and POP3 mail stores
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"github.com/go-waitfor/waitfor/internal/tlsconfig"
+)
+
+const (
+	IMAPScheme       = "imap"
+	IMAPSecureScheme = "imaps"
+	POP3Scheme       = "pop3"
+	POP3SecureScheme = "pop3s"
+
+	DefaultTimeout = 5 * time.Second
+)
+
+var defaultPorts = map[string]string{
+	IMAPScheme:       "143",
+	IMAPSecureScheme: "993",
+	POP3Scheme:       "110",
+	POP3SecureScheme: "995",
+}
+
+type (
+	// IMAP reads the greeting and lists server capabilities, e.g. imaps://mail.local:993
+	IMAP struct {
+		server
+	}
+
+	// POP3 reads the greeting and lists server capabilities, e.g. pop3://mail.local:110
+	POP3 struct {
+		server
+	}
+
+	server struct {
+		addr      string
+		timeout   time.Duration
+		tlsConfig *tls.Config
+	}
+)
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{IMAPScheme, IMAPSecureScheme, POP3Scheme, POP3SecureScheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	port, ok := defaultPorts[u.Scheme]
+
+	if !ok {
+		return nil, fmt.Errorf("%q: unknown scheme %q: %w", "url", u.Scheme, waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	s := server{addr: u.Host}
+
+	if u.Port() == "" {
+		s.addr = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	var err error
+
+	if s.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	if strings.HasSuffix(u.Scheme, "s") {
+		if s.tlsConfig, err = tlsconfig.FromQuery(q, &tls.Config{ServerName: u.Hostname()}); err != nil {
+			return nil, err
+		}
+	}
+
+	if strings.HasPrefix(u.Scheme, IMAPScheme) {
+		return &IMAP{s}, nil
+	}
+
+	return &POP3{s}, nil
+}
+
+// dial connects to the server, the deadline of the connection bounds the whole exchange
+func (s *server) dial(ctx context.Context) (*textproto.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var conn net.Conn
+	var err error
+
+	if s.tlsConfig != nil {
+		d := tls.Dialer{Config: s.tlsConfig}
+		conn, err = d.DialContext(ctx, "tcp", s.addr)
+	} else {
+		var d net.Dialer
+		conn, err = d.DialContext(ctx, "tcp", s.addr)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, _ := ctx.Deadline()
+	_ = conn.SetDeadline(deadline)
+
+	return textproto.NewConn(conn), nil
+}
+
+func (i *IMAP) Test(ctx context.Context) error {
+	conn, err := i.dial(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	greeting, err := conn.ReadLine()
+
+	if err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(greeting, "* OK") && !strings.HasPrefix(greeting, "* PREAUTH") {
+		return fmt.Errorf("unexpected greeting %q", greeting)
+	}
+
+	if err := conn.PrintfLine("a1 CAPABILITY"); err != nil {
+		return err
+	}
+
+	capable := false
+
+	for {
+		line, err := conn.ReadLine()
+
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(line, "* CAPABILITY ") {
+			capable = true
+			continue
+		}
+
+		if status, ok := strings.CutPrefix(line, "a1 "); ok {
+			if !strings.HasPrefix(status, "OK") || !capable {
+				return fmt.Errorf("capability: %s", status)
+			}
+
+			break
+		}
+	}
+
+	_ = conn.PrintfLine("a2 LOGOUT")
+
+	return nil
+}
+
+func (p *POP3) Test(ctx context.Context) error {
+	conn, err := p.dial(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	greeting, err := conn.ReadLine()
+
+	if err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(greeting, "+OK") {
+		return fmt.Errorf("unexpected greeting %q", greeting)
+	}
+
+	if err := conn.PrintfLine("CAPA"); err != nil {
+		return err
+	}
+
+	status, err := conn.ReadLine()
+
+	if err != nil {
+		return err
+	}
+
+	// servers without the CAPA extension answer with an error but are still ready
+	if strings.HasPrefix(status, "+OK") {
+		if _, err := conn.ReadDotLines(); err != nil {
+			return fmt.Errorf("capa: %w", err)
+		}
+	} else if !strings.HasPrefix(status, "-ERR") {
+		return fmt.Errorf("capa: unexpected answer %q", status)
+	}
+
+	_ = conn.PrintfLine("QUIT")
+
+	return nil
+}
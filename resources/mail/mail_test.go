@@ -0,0 +1,101 @@
+package mail
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+// serve starts a fake server sending a greeting and answering commands with canned replies
+func serve(t *testing.T, greeting string, replies map[string][]string) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			c, err := l.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go func() {
+				conn := textproto.NewConn(c)
+				defer conn.Close()
+
+				_ = conn.PrintfLine("%s", greeting)
+
+				for {
+					cmd, err := conn.ReadLine()
+
+					if err != nil {
+						return
+					}
+
+					for _, line := range replies[cmd] {
+						_ = conn.PrintfLine("%s", line)
+					}
+				}
+			}()
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func TestMail_Test(t *testing.T) {
+	imap := serve(t, "* OK IMAP4rev1 ready", map[string][]string{
+		"a1 CAPABILITY": {"* CAPABILITY IMAP4rev1 STARTTLS", "a1 OK CAPABILITY completed"},
+	})
+	imapBusy := serve(t, "* BYE too many connections", nil)
+	pop3 := serve(t, "+OK POP3 ready", map[string][]string{
+		"CAPA": {"+OK", "TOP", "UIDL", "."},
+	})
+	pop3NoCapa := serve(t, "+OK POP3 ready", map[string][]string{
+		"CAPA": {"-ERR unknown command"},
+	})
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"imap://" + imap, true},
+		{"imap://" + imapBusy, false},
+		{"imap://" + pop3, false},
+		{"pop3://" + pop3, true},
+		{"pop3://" + pop3NoCapa, true},
+		{"pop3://" + imap, false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"smtp://localhost", "imap://localhost?timeout=x", "pop3s://localhost?ca=missing.pem"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
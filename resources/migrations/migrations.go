@@ -0,0 +1,295 @@
+// Package migrations provides a resource checking schema versions recorded by database migration tools
+package migrations
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+const (
+	Scheme = "migrations"
+
+	DefaultFormat = "golang-migrate"
+)
+
+// identifier restricts custom table names as they cannot be passed as query arguments
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+type (
+	// Migrations waits for a schema version table to report at least a given version without a failed or dirty migration,
+	// e.g. migrations://postgres?dsn=postgres://user:pass@db/app&format=goose&version=20240115093000.
+	// Supported formats are golang-migrate, goose and flyway, the table defaults to the one of the tool.
+	// The driver must be registered by the caller, e.g. by importing github.com/lib/pq.
+	Migrations struct {
+		driver  string
+		dsn     string
+		format  format
+		table   string
+		version version
+	}
+
+	format struct {
+		table string
+		// current returns the current version, an empty version if nothing is applied yet
+		current func(ctx context.Context, db *dbsql.DB, table string) (string, error)
+	}
+
+	// version is a dotted sequence of numbers, e.g. 20240115093000 or 1.2.3
+	version []int
+)
+
+var formats = map[string]format{
+	"golang-migrate": {"schema_migrations", golangMigrateVersion},
+	"goose":          {"goose_db_version", gooseVersion},
+	"flyway":         {"flyway_schema_history", flywayVersion},
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("%q: %w", "driver", waitfor.ErrInvalidArgument)
+	}
+
+	if q.Get("dsn") == "" {
+		return nil, fmt.Errorf("%q: %w", "dsn", waitfor.ErrInvalidArgument)
+	}
+
+	m := &Migrations{
+		driver: u.Host,
+		dsn:    q.Get("dsn"),
+	}
+
+	name := DefaultFormat
+
+	if q.Has("format") {
+		name = q.Get("format")
+	}
+
+	var ok bool
+
+	if m.format, ok = formats[name]; !ok {
+		return nil, fmt.Errorf("%q: unknown format %q: %w", "format", name, waitfor.ErrInvalidArgument)
+	}
+
+	m.table = m.format.table
+
+	if q.Has("table") {
+		if m.table = q.Get("table"); !identifier.MatchString(m.table) {
+			return nil, fmt.Errorf("%q: invalid name %q: %w", "table", m.table, waitfor.ErrInvalidArgument)
+		}
+	}
+
+	if q.Has("version") {
+		var err error
+
+		if m.version, err = parseVersion(q.Get("version")); err != nil {
+			return nil, fmt.Errorf("%q: %s: %w", "version", err, waitfor.ErrInvalidArgument)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Migrations) Test(ctx context.Context) error {
+	db, err := dbsql.Open(m.driver, m.dsn)
+
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	current, err := m.format.current(ctx, db, m.table)
+
+	if err != nil {
+		return err
+	}
+
+	if current == "" {
+		return fmt.Errorf("no migrations applied")
+	}
+
+	applied, err := parseVersion(current)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", m.table, err)
+	}
+
+	if applied.compare(m.version) < 0 {
+		return fmt.Errorf("schema version is %s, expected at least %s", applied, m.version)
+	}
+
+	return nil
+}
+
+// golangMigrateVersion reads the single row of golang-migrate, a dirty flag is left by a failed migration
+func golangMigrateVersion(ctx context.Context, db *dbsql.DB, table string) (string, error) {
+	var (
+		current int64
+		dirty   bool
+	)
+
+	err := db.QueryRowContext(ctx, "SELECT version, dirty FROM "+table).Scan(&current, &dirty)
+
+	if errors.Is(err, dbsql.ErrNoRows) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if dirty {
+		return "", fmt.Errorf("schema is dirty at version %d", current)
+	}
+
+	return strconv.FormatInt(current, 10), nil
+}
+
+// gooseVersion replays the goose log from the latest entry, rolled back versions are skipped
+func gooseVersion(ctx context.Context, db *dbsql.DB, table string) (string, error) {
+	rows, err := db.QueryContext(ctx, "SELECT version_id, is_applied FROM "+table+" ORDER BY id DESC")
+
+	if err != nil {
+		return "", err
+	}
+
+	defer rows.Close()
+
+	rolledBack := make(map[int64]bool)
+
+	for rows.Next() {
+		var (
+			current int64
+			applied bool
+		)
+
+		if err := rows.Scan(&current, &applied); err != nil {
+			return "", err
+		}
+
+		if rolledBack[current] {
+			continue
+		}
+
+		if !applied {
+			rolledBack[current] = true
+			continue
+		}
+
+		// goose inserts version 0 when it creates the table
+		if current == 0 {
+			return "", nil
+		}
+
+		return strconv.FormatInt(current, 10), rows.Err()
+	}
+
+	return "", rows.Err()
+}
+
+// flywayVersion returns the version of the latest successful versioned migration, failed migrations block the schema
+func flywayVersion(ctx context.Context, db *dbsql.DB, table string) (string, error) {
+	rows, err := db.QueryContext(ctx, "SELECT version, success FROM "+table+" ORDER BY installed_rank DESC")
+
+	if err != nil {
+		return "", err
+	}
+
+	defer rows.Close()
+
+	var current string
+
+	for rows.Next() {
+		var (
+			v       dbsql.NullString
+			success bool
+		)
+
+		if err := rows.Scan(&v, &success); err != nil {
+			return "", err
+		}
+
+		if !success {
+			return "", fmt.Errorf("migration %s failed", v.String)
+		}
+
+		// repeatable migrations have no version
+		if current == "" && v.Valid {
+			current = v.String
+		}
+	}
+
+	return current, rows.Err()
+}
+
+func parseVersion(s string) (version, error) {
+	var v version
+
+	for _, part := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(part)
+
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid version %q", s)
+		}
+
+		v = append(v, n)
+	}
+
+	return v, nil
+}
+
+// compare compares versions part by part, missing parts are zeros
+func (v version) compare(other version) int {
+	for i := 0; i < len(v) || i < len(other); i++ {
+		var a, b int
+
+		if i < len(v) {
+			a = v[i]
+		}
+
+		if i < len(other) {
+			b = other[i]
+		}
+
+		if a != b {
+			if a < b {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func (v version) String() string {
+	parts := make([]string, len(v))
+
+	for i, n := range v {
+		parts[i] = strconv.Itoa(n)
+	}
+
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,146 @@
+package migrations
+
+import (
+	"context"
+	dbsql "database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDriver serves version table rows by dsn, queries must select from the table of the dsn
+type (
+	fakeDriver struct{}
+	fakeConn   struct{ dsn string }
+	fakeStmt   struct {
+		dsn   string
+		query string
+	}
+	fakeRows struct {
+		rows [][]driver.Value
+	}
+)
+
+var tables = map[string]struct {
+	table string
+	rows  [][]driver.Value
+}{
+	"migrate":       {"schema_migrations", [][]driver.Value{{int64(3), false}}},
+	"migrate-dirty": {"schema_migrations", [][]driver.Value{{int64(4), true}}},
+	"migrate-empty": {"schema_migrations", nil},
+	"goose": {"goose_db_version", [][]driver.Value{
+		{int64(20240301000000), false},
+		{int64(20240301000000), true},
+		{int64(20240201000000), true},
+		{int64(0), true},
+	}},
+	"goose-empty": {"goose_db_version", [][]driver.Value{{int64(0), true}}},
+	"flyway": {"flyway_schema_history", [][]driver.Value{
+		{nil, true},
+		{"1.10", true},
+		{"1.9", true},
+	}},
+	"flyway-failed": {"flyway_schema_history", [][]driver.Value{{"2.0", false}, {"1.10", true}}},
+	"custom":        {"app.versions", [][]driver.Value{{int64(7), false}}},
+}
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	if _, ok := tables[dsn]; !ok {
+		return nil, errors.New("connection refused")
+	}
+
+	return fakeConn{dsn}, nil
+}
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return &fakeStmt{c.dsn, query}, nil }
+func (fakeConn) Close() error                                { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                   { return nil, errors.New("not supported") }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return 0 }
+func (s *fakeStmt) Exec(_ []driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (s *fakeStmt) Query(_ []driver.Value) (driver.Rows, error) {
+	if t := tables[s.dsn]; strings.Contains(s.query, " FROM "+t.table) {
+		return &fakeRows{t.rows}, nil
+	}
+
+	return nil, errors.New("no such table")
+}
+
+func (r *fakeRows) Columns() []string { return []string{"version", "flag"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+
+	return nil
+}
+
+func init() {
+	dbsql.Register("fake", fakeDriver{})
+}
+
+func TestMigrations_Test(t *testing.T) {
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"migrations://fake?dsn=migrate", true},
+		{"migrations://fake?dsn=migrate&version=3", true},
+		{"migrations://fake?dsn=migrate&version=4", false},
+		{"migrations://fake?dsn=migrate-dirty", false},
+		{"migrations://fake?dsn=migrate-empty", false},
+		{"migrations://fake?dsn=down", false},
+		{"migrations://fake?dsn=goose&format=goose&version=20240201000000", true},
+		{"migrations://fake?dsn=goose&format=goose&version=20240301000000", false},
+		{"migrations://fake?dsn=goose-empty&format=goose", false},
+		{"migrations://fake?dsn=flyway&format=flyway&version=1.9.1", true},
+		{"migrations://fake?dsn=flyway&format=flyway&version=1.10", true},
+		{"migrations://fake?dsn=flyway&format=flyway&version=2", false},
+		{"migrations://fake?dsn=flyway-failed&format=flyway", false},
+		{"migrations://fake?dsn=flyway&version=1", false},
+		{"migrations://fake?dsn=custom&table=app.versions&version=7", true},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{
+		"migrations://fake",
+		"migrations://?dsn=migrate",
+		"migrations://fake?dsn=migrate&format=liquibase",
+		"migrations://fake?dsn=migrate&table=versions%3BDROP+TABLE+users",
+		"migrations://fake?dsn=migrate&version=v1",
+	} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
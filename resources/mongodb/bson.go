@@ -0,0 +1,186 @@
+package mongodb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var errMalformed = errors.New("malformed bson document")
+
+// element is a key-value pair of a document, the order matters for commands
+type element struct {
+	key   string
+	value interface{}
+}
+
+// encodeDocument encodes a document with int32 and string values
+func encodeDocument(elements ...element) ([]byte, error) {
+	doc := []byte{0, 0, 0, 0}
+
+	for _, e := range elements {
+		switch v := e.value.(type) {
+		case int32:
+			doc = append(doc, 0x10)
+			doc = append(append(doc, e.key...), 0)
+			doc = binary.LittleEndian.AppendUint32(doc, uint32(v))
+		case string:
+			doc = append(doc, 0x02)
+			doc = append(append(doc, e.key...), 0)
+			doc = binary.LittleEndian.AppendUint32(doc, uint32(len(v)+1))
+			doc = append(append(doc, v...), 0)
+		default:
+			return nil, fmt.Errorf("%q: unsupported bson value %T", e.key, v)
+		}
+	}
+
+	doc = append(doc, 0)
+	binary.LittleEndian.PutUint32(doc, uint32(len(doc)))
+
+	return doc, nil
+}
+
+// decodeDocument decodes a document into a map, values of types irrelevant for
+// readiness checks like binary data or object ids are skipped
+func decodeDocument(data []byte) (map[string]interface{}, error) {
+	if len(data) < 5 || int(binary.LittleEndian.Uint32(data)) != len(data) || data[len(data)-1] != 0 {
+		return nil, errMalformed
+	}
+
+	doc := make(map[string]interface{})
+	data = data[4 : len(data)-1]
+
+	for len(data) > 0 {
+		kind := data[0]
+		key, rest, err := cstring(data[1:])
+
+		if err != nil {
+			return nil, err
+		}
+
+		value, size, err := decodeValue(kind, rest)
+
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", key, err)
+		}
+
+		if value != nil {
+			doc[key] = value
+		}
+
+		data = rest[size:]
+	}
+
+	return doc, nil
+}
+
+// decodeValue returns a decoded value and the number of bytes it takes
+func decodeValue(kind byte, data []byte) (interface{}, int, error) {
+	fixed := func(size int) error {
+		if len(data) < size {
+			return errMalformed
+		}
+
+		return nil
+	}
+
+	switch kind {
+	case 0x01: // double
+		if err := fixed(8); err != nil {
+			return nil, 0, err
+		}
+
+		return math.Float64frombits(binary.LittleEndian.Uint64(data)), 8, nil
+	case 0x02, 0x0D, 0x0E: // string, javascript, symbol
+		if err := fixed(4); err != nil {
+			return nil, 0, err
+		}
+
+		size := int(int32(binary.LittleEndian.Uint32(data)))
+
+		if size < 1 || len(data) < 4+size || data[3+size] != 0 {
+			return nil, 0, errMalformed
+		}
+
+		return string(data[4 : 3+size]), 4 + size, nil
+	case 0x03, 0x04: // document, array
+		if err := fixed(4); err != nil {
+			return nil, 0, err
+		}
+
+		size := int(int32(binary.LittleEndian.Uint32(data)))
+
+		if size < 5 || len(data) < size {
+			return nil, 0, errMalformed
+		}
+
+		doc, err := decodeDocument(data[:size])
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if kind == 0x03 {
+			return doc, size, nil
+		}
+
+		arr := make([]interface{}, len(doc))
+
+		for i := range arr {
+			arr[i] = doc[fmt.Sprint(i)]
+		}
+
+		return arr, size, nil
+	case 0x05: // binary
+		if err := fixed(5); err != nil {
+			return nil, 0, err
+		}
+
+		size := int(int32(binary.LittleEndian.Uint32(data)))
+
+		if size < 0 || len(data) < 5+size {
+			return nil, 0, errMalformed
+		}
+
+		return nil, 5 + size, nil
+	case 0x06, 0x0A, 0x7F, 0xFF: // undefined, null, max key, min key
+		return nil, 0, nil
+	case 0x07: // object id
+		return nil, 12, fixed(12)
+	case 0x08: // bool
+		if err := fixed(1); err != nil {
+			return nil, 0, err
+		}
+
+		return data[0] == 1, 1, nil
+	case 0x09, 0x11: // datetime, timestamp
+		return nil, 8, fixed(8)
+	case 0x10: // int32
+		if err := fixed(4); err != nil {
+			return nil, 0, err
+		}
+
+		return int64(int32(binary.LittleEndian.Uint32(data))), 4, nil
+	case 0x12: // int64
+		if err := fixed(8); err != nil {
+			return nil, 0, err
+		}
+
+		return int64(binary.LittleEndian.Uint64(data)), 8, nil
+	case 0x13: // decimal128
+		return nil, 16, fixed(16)
+	}
+
+	return nil, 0, fmt.Errorf("unsupported bson type 0x%02x", kind)
+}
+
+func cstring(data []byte) (string, []byte, error) {
+	for i, b := range data {
+		if b == 0 {
+			return string(data[:i]), data[i+1:], nil
+		}
+	}
+
+	return "", nil, errMalformed
+}
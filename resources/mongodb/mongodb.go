@@ -0,0 +1,260 @@
+// Package mongodb provides a resource checking MongoDB servers and replica sets
+package mongodb
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"github.com/go-waitfor/waitfor/internal/tlsconfig"
+)
+
+const (
+	Scheme = "mongodb"
+
+	DefaultTimeout = 5 * time.Second
+
+	opMsg          = 2013
+	maxMessageSize = 16 * 1024 * 1024
+)
+
+var requestID int32
+
+// MongoDB runs the hello command (isMaster on older servers) against the first reachable host and
+// optionally requires an elected primary or a minimum number of replica set members,
+// e.g. mongodb://db1:27017,db2:27017/?primary=true&minMembers=3
+type MongoDB struct {
+	hosts      []string
+	primary    bool
+	minMembers int
+	timeout    time.Duration
+	tlsConfig  *tls.Config
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	m := &MongoDB{}
+
+	for _, host := range strings.Split(u.Host, ",") {
+		if host == "" {
+			return nil, fmt.Errorf("%q: empty host: %w", "url", waitfor.ErrInvalidArgument)
+		}
+
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			host = net.JoinHostPort(strings.Trim(host, "[]"), "27017")
+		}
+
+		m.hosts = append(m.hosts, host)
+	}
+
+	var err error
+
+	if m.primary, err = query.Bool(q, "primary", false); err != nil {
+		return nil, err
+	}
+
+	if m.minMembers, err = query.Int(q, "minMembers", 0); err != nil {
+		return nil, err
+	}
+
+	if m.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	secure, err := query.Bool(q, "tls", false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if secure {
+		if m.tlsConfig, err = tlsconfig.FromQuery(q, &tls.Config{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+func (m *MongoDB) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	var errs []error
+
+	for _, host := range m.hosts {
+		reply, err := m.hello(ctx, host)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", host, err))
+			continue
+		}
+
+		return m.check(reply)
+	}
+
+	return errors.Join(errs...)
+}
+
+// check validates a hello reply against the required topology
+func (m *MongoDB) check(reply map[string]interface{}) error {
+	if m.primary {
+		writable := reply["isWritablePrimary"] == true || reply["ismaster"] == true
+
+		if _, elected := reply["primary"]; !writable && !elected {
+			return errors.New("no primary elected")
+		}
+	}
+
+	if m.minMembers > 0 {
+		members := 0
+
+		for _, field := range []string{"hosts", "passives", "arbiters"} {
+			list, _ := reply[field].([]interface{})
+			members += len(list)
+		}
+
+		if members < m.minMembers {
+			return fmt.Errorf("%d replica set members, expected at least %d", members, m.minMembers)
+		}
+	}
+
+	return nil
+}
+
+// hello runs the hello command and falls back to isMaster for servers not supporting it
+func (m *MongoDB) hello(ctx context.Context, host string) (map[string]interface{}, error) {
+	c, err := m.dial(ctx, host)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer c.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = c.SetDeadline(deadline)
+	}
+
+	var reply map[string]interface{}
+
+	for _, command := range []string{"hello", "isMaster"} {
+		reply, err = runCommand(c, command)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if succeeded(reply) {
+			return reply, nil
+		}
+	}
+
+	return nil, fmt.Errorf("command failed: %v", reply["errmsg"])
+}
+
+// succeeded reports whether the ok field of a reply is set, servers encode it as a double or an integer
+func succeeded(reply map[string]interface{}) bool {
+	switch ok := reply["ok"].(type) {
+	case float64:
+		return ok == 1
+	case int64:
+		return ok == 1
+	}
+
+	return false
+}
+
+func (m *MongoDB) dial(ctx context.Context, host string) (net.Conn, error) {
+	if m.tlsConfig == nil {
+		var d net.Dialer
+
+		return d.DialContext(ctx, "tcp", host)
+	}
+
+	d := tls.Dialer{Config: m.tlsConfig}
+
+	return d.DialContext(ctx, "tcp", host)
+}
+
+// runCommand sends an OP_MSG with a single admin command and decodes the reply body
+func runCommand(rw io.ReadWriter, command string) (map[string]interface{}, error) {
+	doc, err := encodeDocument(element{command, int32(1)}, element{"$db", "admin"})
+
+	if err != nil {
+		return nil, err
+	}
+
+	msg := make([]byte, 16, 21+len(doc))
+	binary.LittleEndian.PutUint32(msg[4:], uint32(atomic.AddInt32(&requestID, 1)))
+	binary.LittleEndian.PutUint32(msg[12:], opMsg)
+	msg = append(msg, 0, 0, 0, 0, 0) // flags and body section kind
+	msg = append(msg, doc...)
+	binary.LittleEndian.PutUint32(msg, uint32(len(msg)))
+
+	if _, err := rw.Write(msg); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 16)
+
+	if _, err := io.ReadFull(rw, header); err != nil {
+		return nil, err
+	}
+
+	size := int(int32(binary.LittleEndian.Uint32(header)))
+
+	if size < 21 || size > maxMessageSize {
+		return nil, fmt.Errorf("invalid message size %d", size)
+	}
+
+	if code := binary.LittleEndian.Uint32(header[12:]); code != opMsg {
+		return nil, fmt.Errorf("unexpected op code %d", code)
+	}
+
+	body := make([]byte, size-16)
+
+	if _, err := io.ReadFull(rw, body); err != nil {
+		return nil, err
+	}
+
+	// the body section follows the flags, a checksum may trail the document
+	if body[4] != 0 {
+		return nil, fmt.Errorf("unexpected section kind %d", body[4])
+	}
+
+	section := body[5:]
+
+	if len(section) < 4 {
+		return nil, errMalformed
+	}
+
+	docSize := int(int32(binary.LittleEndian.Uint32(section)))
+
+	if docSize < 5 || docSize > len(section) {
+		return nil, errMalformed
+	}
+
+	return decodeDocument(section[:docSize])
+}
@@ -0,0 +1,173 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"math"
+	"net"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+// raw builds a document of pre-encoded elements
+func raw(elements ...[]byte) []byte {
+	doc := []byte{0, 0, 0, 0}
+
+	for _, e := range elements {
+		doc = append(doc, e...)
+	}
+
+	doc = append(doc, 0)
+	binary.LittleEndian.PutUint32(doc, uint32(len(doc)))
+
+	return doc
+}
+
+func double(key string, v float64) []byte {
+	return binary.LittleEndian.AppendUint64(append([]byte{0x01}, key+"\x00"...), math.Float64bits(v))
+}
+
+func boolean(key string, v bool) []byte {
+	if v {
+		return append([]byte{0x08}, key+"\x00\x01"...)
+	}
+
+	return append([]byte{0x08}, key+"\x00\x00"...)
+}
+
+func array(key string, items ...string) []byte {
+	var elements [][]byte
+
+	for i, item := range items {
+		// a single element document without the length prefix and the terminator
+		doc, _ := encodeDocument(element{strconv.Itoa(i), item})
+		elements = append(elements, doc[4:len(doc)-1])
+	}
+
+	return append(append([]byte{0x04}, key+"\x00"...), raw(elements...)...)
+}
+
+// serve starts a fake server answering every command with a given reply
+func serve(t *testing.T, reply []byte) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			c, err := l.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer c.Close()
+
+				header := make([]byte, 16)
+
+				for {
+					if _, err := io.ReadFull(c, header); err != nil {
+						return
+					}
+
+					size := binary.LittleEndian.Uint32(header)
+
+					if _, err := io.ReadFull(c, make([]byte, size-16)); err != nil {
+						return
+					}
+
+					msg := make([]byte, 16, 21+len(reply))
+					binary.LittleEndian.PutUint32(msg[8:], binary.LittleEndian.Uint32(header[4:]))
+					binary.LittleEndian.PutUint32(msg[12:], opMsg)
+					msg = append(append(msg, 0, 0, 0, 0, 0), reply...)
+					binary.LittleEndian.PutUint32(msg, uint32(len(msg)))
+
+					_, _ = c.Write(msg)
+				}
+			}()
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func TestMongoDB_Test(t *testing.T) {
+	standalone := serve(t, raw(boolean("isWritablePrimary", true), double("ok", 1)))
+	secondary := serve(t, raw(
+		boolean("isWritablePrimary", false),
+		array("hosts", "db1:27017", "db2:27017"),
+		double("ok", 1),
+	))
+	electing := serve(t, raw(
+		boolean("isWritablePrimary", false),
+		array("hosts", "db1:27017", "db2:27017", "db3:27017"),
+		double("ok", 1),
+	))
+	failing := serve(t, raw(double("ok", 0)))
+
+	l, _ := net.Listen("tcp", "127.0.0.1:0")
+	closed := l.Addr().String()
+	l.Close()
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"mongodb://" + standalone, true},
+		{"mongodb://" + standalone + "/?primary=true", true},
+		{"mongodb://" + closed + "," + standalone, true},
+		{"mongodb://" + closed, false},
+		{"mongodb://" + failing, false},
+		{"mongodb://" + secondary + "/?minMembers=2", true},
+		{"mongodb://" + secondary + "/?minMembers=3", false},
+		{"mongodb://" + electing + "/?minMembers=3", true},
+		{"mongodb://" + electing + "/?primary=true", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"mongodb://db1,,db2", "mongodb://db?primary=yes", "mongodb://db?minMembers=x", "mongodb://db?tls=true&ca=missing.pem"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
+
+func TestEncodeDocument(t *testing.T) {
+	doc, err := encodeDocument(element{"ping", int32(1)}, element{"$db", "admin"})
+
+	assert.NoError(t, err)
+
+	decoded, err := decodeDocument(doc)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"ping": int64(1), "$db": "admin"}, decoded)
+
+	_, err = encodeDocument(element{"ping", true})
+
+	assert.ErrorContains(t, err, "unsupported bson value bool")
+}
@@ -0,0 +1,53 @@
+package mysql
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	u, _ := url.Parse("mysql://user:secret@db/app?readOnly=false&parseTime=true&timeout=3s")
+	rsc, err := New(u)
+
+	assert.NoError(t, err)
+
+	m := rsc.(*MySQL)
+
+	assert.Equal(t, "db:3306", m.config.Addr)
+	assert.Equal(t, "app", m.config.DBName)
+	assert.Equal(t, "user", m.config.User)
+	assert.Equal(t, "secret", m.config.Passwd)
+	assert.Equal(t, 3*time.Second, m.config.Timeout)
+	assert.True(t, m.config.ParseTime)
+	assert.False(t, *m.readOnly)
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"mysql://db/app?expect=1", "mysql://db/app?readOnly=maybe", "mysql://db/app?tls=unknown"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
+
+func TestMySQL_Test_Unavailable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	addr := l.Addr().String()
+	l.Close()
+
+	u, _ := url.Parse("mariadb://user@" + addr + "/app")
+	rsc, err := New(u)
+
+	assert.NoError(t, err)
+	assert.Error(t, rsc.Test(context.Background()))
+}
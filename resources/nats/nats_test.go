@@ -0,0 +1,98 @@
+package nats
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+// serve starts a fake server accepting a given token
+func serve(t *testing.T, info, token string) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			c, err := l.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer c.Close()
+
+				fmt.Fprintf(c, "INFO %s\r\n", info)
+
+				r := bufio.NewReader(c)
+				connect, _ := readLine(r)
+
+				if _, err := readLine(r); err != nil {
+					return
+				}
+
+				if token != "" && !strings.Contains(connect, `"auth_token":"`+token+`"`) {
+					fmt.Fprint(c, "-ERR 'Authorization Violation'\r\n")
+					return
+				}
+
+				fmt.Fprint(c, "PONG\r\n")
+			}()
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func TestNATS_Test(t *testing.T) {
+	plain := serve(t, `{"server_id":"a"}`, "")
+	jetStream := serve(t, `{"server_id":"b","jetstream":true}`, "")
+	secured := serve(t, `{"server_id":"c"}`, "s3cr3t")
+	tlsOnly := serve(t, `{"server_id":"d","tls_required":true}`, "")
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"nats://" + plain, true},
+		{"nats://" + plain + "?jetstream=true", false},
+		{"nats://" + jetStream + "?jetstream=true", true},
+		{"nats://s3cr3t@" + secured, true},
+		{"nats://wrong@" + secured, false},
+		{"nats://" + tlsOnly, false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"nats://localhost?jetstream=maybe", "nats://localhost?timeout=x", "tls-nats://localhost?ca=missing.pem"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
@@ -0,0 +1,162 @@
+// Package ntp provides a resource checking NTP servers and the local clock offset
+package ntp
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+const (
+	Scheme = "ntp"
+
+	DefaultTimeout    = 5 * time.Second
+	DefaultMaxStratum = 15
+	DefaultMaxOffset  = time.Second
+
+	packetSize = 48
+	// ntpEpoch is the offset of the NTP epoch (1900) from the Unix epoch in seconds
+	ntpEpoch = 2208988800
+	// leapUnsynchronized is a leap indicator of a server without synchronized time
+	leapUnsynchronized = 3
+)
+
+// NTP queries a server and requires it to be synchronized with a stratum not above a limit and
+// the local clock to be within a maximum offset from it, e.g. ntp://pool.ntp.org?maxStratum=3&maxOffset=100ms
+type NTP struct {
+	addr       string
+	maxStratum int
+	maxOffset  time.Duration
+	timeout    time.Duration
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	n := &NTP{addr: u.Host}
+
+	if u.Port() == "" {
+		n.addr = net.JoinHostPort(u.Hostname(), "123")
+	}
+
+	var err error
+
+	if n.maxStratum, err = query.Int(q, "maxStratum", DefaultMaxStratum); err != nil {
+		return nil, err
+	}
+
+	if n.maxOffset, err = query.Duration(q, "maxOffset", DefaultMaxOffset); err != nil {
+		return nil, err
+	}
+
+	if n.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+func (n *NTP) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+
+	var d net.Dialer
+
+	conn, err := d.DialContext(ctx, "udp", n.addr)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	_ = conn.SetDeadline(deadline)
+
+	// version 4 client request, the transmit timestamp is echoed back as the origin timestamp
+	req := make([]byte, packetSize)
+	req[0] = 4<<3 | 3
+	sent := time.Now()
+	binary.BigEndian.PutUint64(req[40:], toNTP(sent))
+
+	if _, err := conn.Write(req); err != nil {
+		return err
+	}
+
+	res := make([]byte, packetSize)
+
+	for {
+		size, err := conn.Read(res)
+
+		if err != nil {
+			return err
+		}
+
+		// stale or spoofed responses do not echo the request timestamp
+		if size >= packetSize && binary.BigEndian.Uint64(res[24:]) == binary.BigEndian.Uint64(req[40:]) {
+			break
+		}
+	}
+
+	received := time.Now()
+
+	if mode := res[0] & 0x7; mode != 4 {
+		return fmt.Errorf("unexpected mode %d", mode)
+	}
+
+	if res[0]>>6 == leapUnsynchronized {
+		return errors.New("server is not synchronized")
+	}
+
+	stratum := int(res[1])
+
+	if stratum == 0 {
+		return fmt.Errorf("kiss of death %q", res[12:16])
+	}
+
+	if stratum > n.maxStratum {
+		return fmt.Errorf("stratum %d is above %d", stratum, n.maxStratum)
+	}
+
+	serverReceived := fromNTP(binary.BigEndian.Uint64(res[32:]))
+	serverSent := fromNTP(binary.BigEndian.Uint64(res[40:]))
+	offset := (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2
+
+	if offset.Abs() > n.maxOffset {
+		return fmt.Errorf("clock offset %s exceeds %s", offset, n.maxOffset)
+	}
+
+	return nil
+}
+
+// toNTP converts time into a 64-bit NTP timestamp of seconds and a fraction
+func toNTP(t time.Time) uint64 {
+	seconds := uint64(t.Unix() + ntpEpoch)
+	fraction := uint64(t.Nanosecond()) << 32 / uint64(time.Second)
+
+	return seconds<<32 | fraction
+}
+
+func fromNTP(ts uint64) time.Time {
+	seconds := int64(ts>>32) - ntpEpoch
+	nanos := (ts & 0xffffffff) * uint64(time.Second) >> 32
+
+	return time.Unix(seconds, int64(nanos))
+}
@@ -0,0 +1,96 @@
+package ntp
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+// serve starts a fake server with a given leap indicator, stratum and clock skew
+func serve(t *testing.T, leap byte, stratum byte, skew time.Duration) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		req := make([]byte, packetSize)
+
+		for {
+			_, addr, err := conn.ReadFrom(req)
+
+			if err != nil {
+				return
+			}
+
+			now := toNTP(time.Now().Add(skew))
+			res := make([]byte, packetSize)
+			res[0] = leap<<6 | 4<<3 | 4
+			res[1] = stratum
+			copy(res[12:], "RATE")
+			copy(res[24:32], req[40:48])
+			binary.BigEndian.PutUint64(res[32:], now)
+			binary.BigEndian.PutUint64(res[40:], now)
+
+			_, _ = conn.WriteTo(res, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestNTP_Test(t *testing.T) {
+	synced := serve(t, 0, 2, 0)
+	skewed := serve(t, 0, 2, 3*time.Second)
+	unsynced := serve(t, leapUnsynchronized, 2, 0)
+	kissOfDeath := serve(t, 0, 0, 0)
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"ntp://" + synced, true},
+		{"ntp://" + synced + "?maxStratum=1", false},
+		{"ntp://" + skewed, false},
+		{"ntp://" + skewed + "?maxOffset=5s", true},
+		{"ntp://" + unsynced, false},
+		{"ntp://" + kissOfDeath, false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	now := time.Now()
+
+	assert.WithinDuration(t, now, fromNTP(toNTP(now)), time.Microsecond)
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"ntp://localhost?maxStratum=x", "ntp://localhost?maxOffset=x", "ntp://localhost?timeout=x"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
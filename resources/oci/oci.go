@@ -0,0 +1,295 @@
+// Package oci provides a resource checking container images in OCI and Docker registries
+package oci
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"github.com/go-waitfor/waitfor/internal/tlsconfig"
+)
+
+const (
+	Scheme       = "oci"
+	DockerScheme = "docker-image"
+
+	DefaultTimeout = 5 * time.Second
+	DefaultTag     = "latest"
+
+	// dockerHub is the registry behind docker.io image names
+	dockerHub = "registry-1.docker.io"
+)
+
+// manifestTypes are accepted manifest and index media types
+var manifestTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// OCI waits for an image tag or digest to be pullable by requesting its manifest,
+// e.g. oci://ghcr.io/acme/api:1.4.2 or docker-image://docker.io/nginx@sha256:<digest>.
+// Registry tokens are requested with credentials from the URL userinfo when the registry asks for them.
+// Registries without TLS are checked with tls=false.
+type OCI struct {
+	image      string
+	repository string
+	manifest   *url.URL
+	user       *url.Userinfo
+	client     *http.Client
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme, DockerScheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	repository, reference := splitReference(strings.Trim(u.Path, "/"))
+
+	if u.Host == "" || repository == "" || reference == "" {
+		return nil, fmt.Errorf("%q: expected registry/repository:tag or registry/repository@digest: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	host := u.Host
+
+	if host == "docker.io" || host == "index.docker.io" {
+		host = dockerHub
+
+		// official images live in the library namespace
+		if !strings.Contains(repository, "/") {
+			repository = "library/" + repository
+		}
+	}
+
+	secure, err := query.Bool(q, "tls", true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	timeout, err := query.Duration(q, "timeout", DefaultTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := tlsconfig.FromQuery(q, &tls.Config{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := "https"
+
+	if !secure {
+		scheme = "http"
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &OCI{
+		image:      u.Host + "/" + strings.Trim(u.Path, "/"),
+		repository: repository,
+		manifest: &url.URL{
+			Scheme: scheme,
+			Host:   host,
+			Path:   "/v2/" + repository + "/manifests/" + reference,
+		},
+		user:   u.User,
+		client: &http.Client{Transport: transport, Timeout: timeout},
+	}, nil
+}
+
+// splitReference splits a path into a repository and a digest or a tag, the latest tag is used by default
+func splitReference(path string) (string, string) {
+	if i := strings.LastIndex(path, "@"); i >= 0 {
+		return path[:i], path[i+1:]
+	}
+
+	if i := strings.LastIndex(path, ":"); i > strings.LastIndex(path, "/") {
+		return path[:i], path[i+1:]
+	}
+
+	return path, DefaultTag
+}
+
+func (o *OCI) Test(ctx context.Context) error {
+	res, err := o.head(ctx, "")
+
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode == http.StatusUnauthorized {
+		auth, err := o.authorize(ctx, res.Header.Get("WWW-Authenticate"))
+
+		if err != nil {
+			return err
+		}
+
+		if res, err = o.head(ctx, auth); err != nil {
+			return err
+		}
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("image %s does not exist", o.image)
+	}
+
+	return fmt.Errorf("image %s: unexpected status code %d", o.image, res.StatusCode)
+}
+
+// head requests the manifest with an optional Authorization header value
+func (o *OCI) head(ctx context.Context, auth string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, o.manifest.String(), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", strings.Join(manifestTypes, ", "))
+
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+
+	res, err := o.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body.Close()
+
+	return res, nil
+}
+
+// authorize answers a registry challenge with basic credentials or a bearer token from the token service
+func (o *OCI) authorize(ctx context.Context, challenge string) (string, error) {
+	scheme, params := parseChallenge(challenge)
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if o.user == nil {
+			return "", fmt.Errorf("image %s: registry requires credentials", o.image)
+		}
+
+		password, _ := o.user.Password()
+
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(o.user.Username()+":"+password)), nil
+	case "bearer":
+		return o.token(ctx, params)
+	}
+
+	return "", fmt.Errorf("image %s: unsupported authentication %q", o.image, scheme)
+}
+
+// token requests a pull token from the realm of a bearer challenge
+func (o *OCI) token(ctx context.Context, params map[string]string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("image %s: invalid token realm %q", o.image, params["realm"])
+	}
+
+	q := realm.Query()
+	q.Set("scope", "repository:"+o.repository+":pull")
+
+	if service := params["service"]; service != "" {
+		q.Set("service", service)
+	}
+
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	if o.user != nil {
+		password, _ := o.user.Password()
+		req.SetBasicAuth(o.user.Username(), password)
+	}
+
+	res, err := o.client.Do(req)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("image %s: token request failed with status code %d", o.image, res.StatusCode)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", err
+	}
+
+	if body.Token == "" {
+		body.Token = body.AccessToken
+	}
+
+	return "Bearer " + body.Token, nil
+}
+
+// parseChallenge parses a WWW-Authenticate header, e.g. Bearer realm="https://auth",service="registry"
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := make(map[string]string)
+
+	for rest = strings.TrimSpace(rest); rest != ""; {
+		var key string
+
+		key, rest, _ = strings.Cut(rest, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		var value string
+
+		// quoted values may contain commas, e.g. scope="repository:a:pull,push"
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+
+		params[key] = value
+		rest = strings.TrimLeft(rest, ", ")
+	}
+
+	return scheme, params
+}
@@ -0,0 +1,119 @@
+package oci
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOCI_Test(t *testing.T) {
+	manifests := map[string]bool{
+		"/v2/acme/api/manifests/1.4.2":           true,
+		"/v2/acme/api/manifests/latest":          true,
+		"/v2/acme/api/manifests/sha256:3f1a9c0e": true,
+	}
+
+	var srv *httptest.Server
+
+	// the fake registry issues tokens to ci:secret with the token service on the same server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			user, password, _ := r.BasicAuth()
+
+			if user != "ci" || password != "secret" || r.URL.Query().Get("scope") != "repository:acme/api:pull" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"token":"pull-token"}`))
+
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer pull-token" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="registry.test",scope="repository:acme/api:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		if !manifests[r.URL.Path] || !strings.Contains(r.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json") {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	host := srv.Listener.Addr().String()
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"oci://ci:secret@" + host + "/acme/api:1.4.2?tls=false", true},
+		{"oci://ci:secret@" + host + "/acme/api?tls=false", true},
+		{"docker-image://ci:secret@" + host + "/acme/api@sha256:3f1a9c0e?tls=false", true},
+		{"oci://ci:secret@" + host + "/acme/api:1.5.0?tls=false", false},
+		{"oci://ci:wrong@" + host + "/acme/api:1.4.2?tls=false", false},
+		{"oci://" + host + "/acme/api:1.4.2?tls=false", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	u, _ := url.Parse("docker-image://docker.io/nginx:1.27")
+	rsc, err := New(u)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://registry-1.docker.io/v2/library/nginx/manifests/1.27", rsc.(*OCI).manifest.String())
+
+	u, _ = url.Parse("oci://localhost:5000/acme/api?tls=false")
+	rsc, err = New(u)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:5000/v2/acme/api/manifests/latest", rsc.(*OCI).manifest.String())
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"oci://", "oci://ghcr.io", "oci://ghcr.io/acme/api@", "oci://ghcr.io/acme/api?tls=maybe", "oci://ghcr.io/acme/api?timeout=x"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/nginx:pull,push"`)
+
+	assert.Equal(t, "Bearer", scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:library/nginx:pull,push",
+	}, params)
+
+	scheme, params = parseChallenge(`Basic realm=registry`)
+
+	assert.Equal(t, "Basic", scheme)
+	assert.Equal(t, map[string]string{"realm": "registry"}, params)
+}
@@ -0,0 +1,141 @@
+// Package oidc provides a resource checking OpenID Connect discovery documents
+package oidc
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"github.com/go-waitfor/waitfor/internal/tlsconfig"
+)
+
+const (
+	Scheme = "oidc"
+
+	DefaultTimeout = 5 * time.Second
+
+	discoveryPath = ".well-known/openid-configuration"
+)
+
+// OIDC waits for an identity provider to publish its discovery document with the issuer,
+// jwks_uri and token_endpoint fields, e.g. oidc://keycloak:8443/realms/main.
+// The issuer must match the URL unless it is set explicitly, e.g. ?issuer=https://id.example.com/realms/main.
+// Providers without TLS are checked with tls=false.
+type OIDC struct {
+	url     *url.URL
+	issuer  string
+	timeout time.Duration
+	client  *http.Client
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("%q: missing host: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	o := &OIDC{
+		url: &url.URL{
+			Scheme: "https",
+			Host:   u.Host,
+			Path:   strings.TrimSuffix(u.Path, "/"),
+		},
+	}
+
+	secure, err := query.Bool(q, "tls", true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !secure {
+		o.url.Scheme = "http"
+	}
+
+	o.issuer = query.String(q, "issuer", o.url.String())
+
+	if o.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if transport.TLSClientConfig, err = tlsconfig.FromQuery(q, &tls.Config{}); err != nil {
+		return nil, err
+	}
+
+	o.client = &http.Client{Transport: transport}
+
+	return o, nil
+}
+
+func (o *OIDC) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.url.JoinPath(discoveryPath).String(), nil)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := o.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("discovery: unexpected status code %d", res.StatusCode)
+	}
+
+	var doc struct {
+		Issuer        string `json:"issuer"`
+		JWKSURI       string `json:"jwks_uri"`
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+
+	if err := json.NewDecoder(io.LimitReader(res.Body, 1024*1024)).Decode(&doc); err != nil {
+		return fmt.Errorf("discovery: %w", err)
+	}
+
+	if doc.Issuer != o.issuer {
+		return fmt.Errorf("discovery: issuer is %q, expected %q", doc.Issuer, o.issuer)
+	}
+
+	if err := checkURL("jwks_uri", doc.JWKSURI); err != nil {
+		return err
+	}
+
+	return checkURL("token_endpoint", doc.TokenEndpoint)
+}
+
+// checkURL checks that a discovery document field is an absolute URL
+func checkURL(field, value string) error {
+	if u, err := url.Parse(value); err != nil || !u.IsAbs() {
+		return fmt.Errorf("discovery: %s is not a valid url: %q", field, value)
+	}
+
+	return nil
+}
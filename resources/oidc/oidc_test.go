@@ -0,0 +1,65 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDC_Test(t *testing.T) {
+	var srv *httptest.Server
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/realms/main/.well-known/openid-configuration":
+			_, _ = w.Write([]byte(`{"issuer":"` + srv.URL + `/realms/main","jwks_uri":"` + srv.URL + `/realms/main/certs","token_endpoint":"` + srv.URL + `/realms/main/token"}`))
+		case "/realms/partial/.well-known/openid-configuration":
+			_, _ = w.Write([]byte(`{"issuer":"` + srv.URL + `/realms/partial","jwks_uri":"` + srv.URL + `/realms/partial/certs"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	host := srv.Listener.Addr().String()
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"oidc://" + host + "/realms/main?tls=false", true},
+		{"oidc://" + host + "/realms/main/?tls=false", true},
+		{"oidc://" + host + "/realms/main?tls=false&issuer=" + url.QueryEscape("https://id.example.com/realms/main"), false},
+		{"oidc://" + host + "/realms/partial?tls=false", false},
+		{"oidc://" + host + "/realms/missing?tls=false", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"oidc:///realms/main", "oidc://id?tls=maybe", "oidc://id?timeout=x"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+
+package pid
+
+import "github.com/go-waitfor/waitfor"
+
+func isAlive(_ int) (bool, error) {
+	return false, waitfor.ErrNotSupported
+}
@@ -0,0 +1,22 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package pid
+
+import (
+	"errors"
+	"syscall"
+)
+
+// isAlive checks a process existence with a zero signal
+func isAlive(pid int) (bool, error) {
+	err := syscall.Kill(pid, 0)
+
+	switch {
+	case err == nil, errors.Is(err, syscall.EPERM):
+		return true, nil
+	case errors.Is(err, syscall.ESRCH):
+		return false, nil
+	default:
+		return false, err
+	}
+}
@@ -0,0 +1,67 @@
+package pid
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// maxCommLen is the length of a process name truncated by the kernel
+const maxCommLen = 15
+
+// listProcesses reads running processes from procfs
+func listProcesses() ([]processInfo, error) {
+	entries, err := os.ReadDir("/proc")
+
+	if err != nil {
+		return nil, err
+	}
+
+	processes := make([]processInfo, 0, len(entries))
+
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+
+		if err != nil {
+			continue
+		}
+
+		dir := filepath.Join("/proc", entry.Name())
+
+		// processes may exit while they are being listed
+		comm, err := os.ReadFile(filepath.Join(dir, "comm"))
+
+		if err != nil {
+			continue
+		}
+
+		cmdline, _ := os.ReadFile(filepath.Join(dir, "cmdline"))
+
+		processes = append(processes, processInfo{
+			pid:     pid,
+			name:    processName(string(comm), string(cmdline)),
+			cmdline: strings.TrimSpace(strings.ReplaceAll(string(cmdline), "\x00", " ")),
+		})
+	}
+
+	return processes, nil
+}
+
+// processName returns the name of a process, comm is truncated by the kernel to 15 bytes,
+// so a longer name is taken from the executable of the command line if it starts with comm
+func processName(comm, cmdline string) string {
+	name := strings.TrimSpace(comm)
+
+	if len(name) < maxCommLen {
+		return name
+	}
+
+	argv0, _, _ := strings.Cut(cmdline, "\x00")
+
+	if base := filepath.Base(argv0); strings.HasPrefix(base, name) {
+		return base
+	}
+
+	return name
+}
@@ -0,0 +1,38 @@
+package pid
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessName(t *testing.T) {
+	cases := []struct {
+		comm     string
+		cmdline  string
+		expected string
+	}{
+		{"sleep\n", "sleep\x0010\x00", "sleep"},
+		{"postgres\n", "postgres: checkpointer\x00", "postgres"},
+		{"kube-controller\n", "/usr/local/bin/kube-controller-manager\x00--leader-elect\x00", "kube-controller-manager"},
+		{"kube-controller\n", "", "kube-controller"},
+		{"kube-controller\n", "/usr/bin/other-process-name\x00", "kube-controller"},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, processName(c.comm, c.cmdline), c.cmdline)
+	}
+}
+
+func TestProcess_Test_Self(t *testing.T) {
+	// the test binary is the only process whose command line contains its own path
+	u := &url.URL{Scheme: ProcessScheme, RawQuery: url.Values{"pattern": {regexp.QuoteMeta(os.Args[0])}, "state": {stateExited}}.Encode()}
+	rsc, err := New(u)
+
+	assert.NoError(t, err)
+	assert.NoError(t, rsc.Test(context.Background()))
+}
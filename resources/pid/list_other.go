@@ -0,0 +1,9 @@
+//go:build !linux
+
+package pid
+
+import "github.com/go-waitfor/waitfor"
+
+func listProcesses() ([]processInfo, error) {
+	return nil, waitfor.ErrNotSupported
+}
@@ -0,0 +1,189 @@
+// Package pid provides resources checking processes by id or by name
+package pid
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+const (
+	Scheme        = "pid"
+	ProcessScheme = "process"
+
+	stateRunning = "running"
+	stateExited  = "exited"
+)
+
+type (
+	// PID checks that a process with a given id, or an id read from a pid file, is alive,
+	// e.g. pid://1234 or pid:///var/run/nginx.pid?state=exited
+	PID struct {
+		id     int
+		file   string
+		exited bool
+	}
+
+	// Process checks that a process with a given name, or a command line matching a pattern, exists,
+	// e.g. process://nginx or process://?pattern=java.*kafka&state=exited.
+	// Like pgrep, the current process and its parent are never matched.
+	Process struct {
+		name    string
+		pattern *regexp.Regexp
+		exited  bool
+	}
+
+	processInfo struct {
+		pid     int
+		name    string
+		cmdline string
+	}
+)
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme, ProcessScheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+
+	var exited bool
+
+	switch state := q.Get("state"); state {
+	case "", stateRunning:
+	case stateExited:
+		exited = true
+	default:
+		return nil, fmt.Errorf("%q: %q: %w", "state", state, waitfor.ErrInvalidArgument)
+	}
+
+	if u.Scheme == ProcessScheme {
+		return newProcess(u, exited)
+	}
+
+	if u.Path != "" {
+		return &PID{file: u.Host + u.Path, exited: exited}, nil
+	}
+
+	id, err := strconv.Atoi(u.Host)
+
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("%q: %q: %w", "pid", u.Host, waitfor.ErrInvalidArgument)
+	}
+
+	return &PID{id: id, exited: exited}, nil
+}
+
+func newProcess(u *url.URL, exited bool) (*Process, error) {
+	p := &Process{
+		name:   u.Host,
+		exited: exited,
+	}
+
+	if value := u.Query().Get("pattern"); value != "" {
+		re, err := regexp.Compile(value)
+
+		if err != nil {
+			return nil, fmt.Errorf("%q: %s: %w", "pattern", err, waitfor.ErrInvalidArgument)
+		}
+
+		p.pattern = re
+	}
+
+	if p.name == "" && p.pattern == nil {
+		return nil, fmt.Errorf("%q: name or pattern is required: %w", "process", waitfor.ErrInvalidArgument)
+	}
+
+	return p, nil
+}
+
+func (p *PID) Test(_ context.Context) error {
+	id := p.id
+
+	if p.file != "" {
+		data, err := os.ReadFile(p.file)
+
+		if err != nil {
+			if p.exited && os.IsNotExist(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		if id, err = strconv.Atoi(strings.TrimSpace(string(data))); err != nil {
+			return fmt.Errorf("%s: invalid pid: %w", p.file, err)
+		}
+	}
+
+	alive, err := isAlive(id)
+
+	if err != nil {
+		return err
+	}
+
+	return checkState(fmt.Sprintf("process %d", id), alive, p.exited)
+}
+
+func (p *Process) Test(_ context.Context) error {
+	processes, err := listProcesses()
+
+	if err != nil {
+		return err
+	}
+
+	found := false
+	// the command line of the current process, e.g. waitfor, and its parent contain the pattern itself
+	self, parent := os.Getpid(), os.Getppid()
+
+	for _, proc := range processes {
+		if proc.pid == self || proc.pid == parent {
+			continue
+		}
+
+		if p.name != "" && proc.name != p.name {
+			continue
+		}
+
+		if p.pattern != nil && !p.pattern.MatchString(proc.cmdline) {
+			continue
+		}
+
+		found = true
+
+		break
+	}
+
+	name := p.name
+
+	if p.pattern != nil {
+		name += " " + p.pattern.String()
+	}
+
+	return checkState(fmt.Sprintf("process %q", strings.TrimSpace(name)), found, p.exited)
+}
+
+func checkState(name string, alive, exited bool) error {
+	if alive && exited {
+		return fmt.Errorf("%s is running", name)
+	}
+
+	if !alive && !exited {
+		return fmt.Errorf("%s is not running", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package pid
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPID_Test(t *testing.T) {
+	cmd := exec.Command("sleep", "10")
+
+	assert.NoError(t, cmd.Start())
+
+	pid := strconv.Itoa(cmd.Process.Pid)
+	pidFile := filepath.Join(t.TempDir(), "sleep.pid")
+
+	assert.NoError(t, os.WriteFile(pidFile, []byte(pid+"\n"), 0600))
+
+	test := func(location string) error {
+		u, _ := url.Parse(location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, location)
+
+		return rsc.Test(context.Background())
+	}
+
+	assert.NoError(t, test("pid://"+pid))
+	assert.NoError(t, test("pid://"+pidFile))
+	assert.Error(t, test("pid://"+pid+"?state=exited"))
+	assert.NoError(t, test("process://sleep?pattern=sleep+10"))
+	assert.Error(t, test("process://sleep?pattern=sleep+20"))
+
+	assert.NoError(t, cmd.Process.Kill())
+	_ = cmd.Wait()
+
+	assert.Error(t, test("pid://"+pid))
+	assert.NoError(t, test("pid://"+pid+"?state=exited"))
+	assert.NoError(t, test("pid://"+filepath.Join(t.TempDir(), "missing.pid")+"?state=exited"))
+}
@@ -0,0 +1,74 @@
+// Package portfree provides a resource waiting for a local port to be released
+package portfree
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+const Scheme = "port-free"
+
+// PortFree checks that a local port is not in use by binding it,
+// e.g. port-free://:8080 or port-free://127.0.0.1:514?network=udp
+type PortFree struct {
+	network string
+	address string
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Port() == "" {
+		return nil, fmt.Errorf("%q: port is required: %w", u.Host, waitfor.ErrInvalidArgument)
+	}
+
+	network := strings.ToLower(query.String(u.Query(), "network", "tcp"))
+
+	switch network {
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6":
+	default:
+		return nil, fmt.Errorf("%q: %q: %w", "network", network, waitfor.ErrInvalidArgument)
+	}
+
+	return &PortFree{
+		network: network,
+		address: u.Host,
+	}, nil
+}
+
+func (p *PortFree) Test(ctx context.Context) error {
+	var lc net.ListenConfig
+
+	if strings.HasPrefix(p.network, "udp") {
+		conn, err := lc.ListenPacket(ctx, p.network, p.address)
+
+		if err != nil {
+			return fmt.Errorf("port is in use: %w", err)
+		}
+
+		return conn.Close()
+	}
+
+	l, err := lc.Listen(ctx, p.network, p.address)
+
+	if err != nil {
+		return fmt.Errorf("port is in use: %w", err)
+	}
+
+	return l.Close()
+}
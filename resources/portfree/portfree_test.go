@@ -0,0 +1,42 @@
+package portfree
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPortFree_Test(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	u, _ := url.Parse("port-free://" + l.Addr().String())
+	rsc, err := New(u)
+
+	assert.NoError(t, err)
+	assert.Error(t, rsc.Test(context.Background()))
+
+	l.Close()
+
+	assert.NoError(t, rsc.Test(context.Background()))
+}
+
+func TestPortFree_Test_UDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	u, _ := url.Parse("port-free://" + conn.LocalAddr().String() + "?network=udp")
+	rsc, err := New(u)
+
+	assert.NoError(t, err)
+	assert.Error(t, rsc.Test(context.Background()))
+
+	conn.Close()
+
+	assert.NoError(t, rsc.Test(context.Background()))
+}
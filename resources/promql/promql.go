@@ -0,0 +1,237 @@
+// Package promql provides a resource comparing results of Prometheus queries with thresholds
+package promql
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"github.com/go-waitfor/waitfor/internal/tlsconfig"
+)
+
+const (
+	Scheme = "promql"
+
+	DefaultTimeout = 5 * time.Second
+)
+
+// operators compare a sample value with a threshold
+var operators = map[string]func(value, threshold float64) bool{
+	"gt":  func(v, t float64) bool { return v > t },
+	"gte": func(v, t float64) bool { return v >= t },
+	"lt":  func(v, t float64) bool { return v < t },
+	"lte": func(v, t float64) bool { return v <= t },
+	"eq":  func(v, t float64) bool { return v == t },
+	"ne":  func(v, t float64) bool { return v != t },
+}
+
+type (
+	// PromQL runs an instant query and waits for every returned sample to satisfy all comparisons,
+	// e.g. promql://prom:9090?query=up{job="db"}&gte=1 or promql://prom:9090?query=sum(rate(errors[1m]))&lt=0.5.
+	// Comparisons are gt, gte, lt, lte, eq and ne; without them any non-empty result is accepted.
+	PromQL struct {
+		url         *url.URL
+		query       string
+		comparisons []comparison
+		timeout     time.Duration
+		client      *http.Client
+	}
+
+	comparison struct {
+		operator  string
+		threshold float64
+	}
+)
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	p := &PromQL{
+		url: &url.URL{
+			Scheme: "http",
+			User:   u.User,
+			Host:   u.Host,
+			Path:   u.Path,
+		},
+		query: q.Get("query"),
+	}
+
+	if p.query == "" {
+		return nil, fmt.Errorf("%q: missing value: %w", "query", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Port() == "" {
+		p.url.Host = net.JoinHostPort(u.Hostname(), "9090")
+	}
+
+	for _, operator := range []string{"gt", "gte", "lt", "lte", "eq", "ne"} {
+		for _, value := range q[operator] {
+			threshold, err := strconv.ParseFloat(value, 64)
+
+			if err != nil {
+				return nil, fmt.Errorf("%q: invalid number %q: %w", operator, value, waitfor.ErrInvalidArgument)
+			}
+
+			p.comparisons = append(p.comparisons, comparison{operator, threshold})
+		}
+	}
+
+	var err error
+
+	if p.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	secure, err := query.Bool(q, "tls", false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if secure {
+		p.url.Scheme = "https"
+
+		if transport.TLSClientConfig, err = tlsconfig.FromQuery(q, &tls.Config{}); err != nil {
+			return nil, err
+		}
+	}
+
+	p.client = &http.Client{Transport: transport}
+
+	return p, nil
+}
+
+func (p *PromQL) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	values, err := p.evaluate(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if len(values) == 0 {
+		return fmt.Errorf("query %s returned no data", p.query)
+	}
+
+	for _, value := range values {
+		for _, c := range p.comparisons {
+			if !operators[c.operator](value, c.threshold) {
+				return fmt.Errorf("query %s returned %g, expected %s %g", p.query, value, c.operator, c.threshold)
+			}
+		}
+	}
+
+	return nil
+}
+
+// evaluate runs the instant query and returns values of a vector or a scalar result
+func (p *PromQL) evaluate(ctx context.Context) ([]float64, error) {
+	u := p.url.JoinPath("api/v1/query")
+	u.RawQuery = url.Values{"query": {p.query}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var body struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   struct {
+			ResultType string          `json:"resultType"`
+			Result     json.RawMessage `json:"result"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(io.LimitReader(res.Body, 1024*1024)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("query %s: unexpected status code %d", p.query, res.StatusCode)
+	}
+
+	if body.Status != "success" {
+		return nil, fmt.Errorf("query %s: %s", p.query, body.Error)
+	}
+
+	switch body.Data.ResultType {
+	case "scalar":
+		var sample [2]interface{}
+
+		if err := json.Unmarshal(body.Data.Result, &sample); err != nil {
+			return nil, err
+		}
+
+		value, err := parseValue(sample)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []float64{value}, nil
+	case "vector":
+		var samples []struct {
+			Value [2]interface{} `json:"value"`
+		}
+
+		if err := json.Unmarshal(body.Data.Result, &samples); err != nil {
+			return nil, err
+		}
+
+		values := make([]float64, 0, len(samples))
+
+		for _, s := range samples {
+			value, err := parseValue(s.Value)
+
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, value)
+		}
+
+		return values, nil
+	}
+
+	return nil, fmt.Errorf("query %s: unsupported result type %q", p.query, body.Data.ResultType)
+}
+
+// parseValue parses a [timestamp, "value"] pair
+func parseValue(sample [2]interface{}) (float64, error) {
+	value, ok := sample[1].(string)
+
+	if !ok {
+		return 0, fmt.Errorf("invalid sample value %v", sample[1])
+	}
+
+	return strconv.ParseFloat(value, 64)
+}
@@ -0,0 +1,75 @@
+package promql
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromQL_Test(t *testing.T) {
+	results := map[string]string{
+		`up{job="db"}`:        `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"instance":"a"},"value":[1700000000,"1"]},{"metric":{"instance":"b"},"value":[1700000000,"0"]}]}}`,
+		`up{job="web"}`:       `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"1"]}]}}`,
+		`up{job="none"}`:      `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		`scalar(queue_depth)`: `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"42"]}}`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result, ok := results[r.URL.Query().Get("query")]
+
+		if r.URL.Path != "/api/v1/query" || !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(result))
+	}))
+	defer srv.Close()
+
+	host := srv.Listener.Addr().String()
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"promql://" + host + "?query=" + url.QueryEscape(`up{job="web"}`) + "&gte=1", true},
+		{"promql://" + host + "?query=" + url.QueryEscape(`up{job="web"}`), true},
+		{"promql://" + host + "?query=" + url.QueryEscape(`up{job="db"}`) + "&gte=1", false},
+		{"promql://" + host + "?query=" + url.QueryEscape(`up{job="db"}`) + "&lt=2", true},
+		{"promql://" + host + "?query=" + url.QueryEscape(`up{job="none"}`), false},
+		{"promql://" + host + "?query=" + url.QueryEscape(`scalar(queue_depth)`) + "&gt=10&lte=42", true},
+		{"promql://" + host + "?query=" + url.QueryEscape(`scalar(queue_depth)`) + "&ne=42", false},
+		{"promql://" + host + "?query=" + url.QueryEscape(`up{`), false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"promql://prom", "promql://prom?query=up&gt=x", "promql://prom?query=up&tls=maybe", "promql://prom?query=up&timeout=x"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
@@ -0,0 +1,147 @@
+// Package pubsub provides a resource checking Google Cloud Pub/Sub topics and subscriptions
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	Scheme = "pubsub"
+
+	DefaultTimeout  = 5 * time.Second
+	DefaultEndpoint = "https://pubsub.googleapis.com"
+
+	// EmulatorHostEnv points the resource to an emulator, requests are not authenticated then
+	EmulatorHostEnv = "PUBSUB_EMULATOR_HOST"
+
+	pubsubScope = "https://www.googleapis.com/auth/pubsub"
+)
+
+// PubSub waits for topics and subscriptions of a project to exist. Both parameters can be repeated,
+// e.g. pubsub://my-project?topic=orders&subscription=orders-worker
+type PubSub struct {
+	base     *url.URL
+	names    []string
+	emulator bool
+	timeout  time.Duration
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("%q: missing project: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	endpoint, emulator := DefaultEndpoint, false
+
+	if host := os.Getenv(EmulatorHostEnv); host != "" {
+		endpoint, emulator = host, true
+
+		if !strings.Contains(host, "://") {
+			endpoint = "http://" + host
+		}
+	}
+
+	if q.Has("endpoint") {
+		endpoint, emulator = q.Get("endpoint"), true
+	}
+
+	base, err := url.Parse(endpoint)
+
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("%q: invalid url: %w", "endpoint", waitfor.ErrInvalidArgument)
+	}
+
+	p := &PubSub{base: base, emulator: emulator}
+
+	for _, topic := range q["topic"] {
+		p.names = append(p.names, "projects/"+u.Host+"/topics/"+topic)
+	}
+
+	for _, subscription := range q["subscription"] {
+		p.names = append(p.names, "projects/"+u.Host+"/subscriptions/"+subscription)
+	}
+
+	if len(p.names) == 0 {
+		return nil, fmt.Errorf("%q: topic or subscription is required: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if p.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *PubSub) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	client := http.DefaultClient
+
+	if !p.emulator {
+		var err error
+
+		if client, err = google.DefaultClient(ctx, pubsubScope); err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+
+	for _, name := range p.names {
+		if err := p.get(ctx, client, name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// get fetches a resource by its name, e.g. projects/p/topics/t
+func (p *PubSub) get(ctx context.Context, client *http.Client, name string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.base.JoinPath("v1", name).String(), nil)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("%s does not exist", name)
+	}
+
+	return fmt.Errorf("%s: unexpected status code %d", name, res.StatusCode)
+}
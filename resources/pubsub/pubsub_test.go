@@ -0,0 +1,65 @@
+package pubsub
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPubSub_Test(t *testing.T) {
+	// the fake server knows the orders topic with the orders-worker subscription
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/projects/shop/topics/orders", "/v1/projects/shop/subscriptions/orders-worker":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv(EmulatorHostEnv, srv.Listener.Addr().String())
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"pubsub://shop?topic=orders", true},
+		{"pubsub://shop?topic=payments", false},
+		{"pubsub://shop?subscription=orders-worker", true},
+		{"pubsub://shop?topic=orders&subscription=orders-worker", true},
+		{"pubsub://shop?topic=orders&subscription=payments-worker", false},
+		{"pubsub://shop?topic=orders&topic=payments", false},
+		{"pubsub://billing?topic=orders", false},
+		{"pubsub://shop?topic=orders&endpoint=" + url.QueryEscape(srv.URL), true},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"pubsub://?topic=orders", "pubsub://shop", "pubsub://shop?topic=orders&endpoint=localhost", "pubsub://shop?topic=orders&timeout=x"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
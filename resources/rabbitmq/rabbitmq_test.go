@@ -0,0 +1,81 @@
+package rabbitmq
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRabbitMQ_Test(t *testing.T) {
+	// the fake management API knows the created queue and the events exchange of the default and orders vhosts
+	objects := map[string]string{
+		"/api/queues/%2F/created":      `{"name":"created","consumers":2}`,
+		"/api/queues/orders/created":   `{"name":"created","consumers":0}`,
+		"/api/exchanges/%2F/events":    `{"name":"events","type":"topic"}`,
+		"/api/exchanges/orders/events": `{"name":"events","type":"topic"}`,
+		"/api/queues/%2F/orders%2Fdlq": `{"name":"orders/dlq","consumers":0}`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, _ := r.BasicAuth(); user != "guest" || password != "guest" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		object, ok := objects[r.URL.EscapedPath()]
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(object))
+	}))
+	defer srv.Close()
+
+	host := "guest:guest@" + srv.Listener.Addr().String()
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"rabbitmq://" + host + "?queue=created", true},
+		{"rabbitmq://" + host + "?queue=created&exchange=events&minConsumers=2", true},
+		{"rabbitmq://" + host + "?queue=created&minConsumers=3", false},
+		{"rabbitmq://" + host + "?queue=created&queue=deleted", false},
+		{"rabbitmq://" + host + "?exchange=audit", false},
+		{"rabbitmq://" + host + "?queue=" + url.QueryEscape("orders/dlq"), true},
+		{"rabbitmq://" + host + "/orders?queue=created&exchange=events", true},
+		{"rabbitmq://" + host + "/orders?queue=created&minConsumers=1", false},
+		{"rabbitmq://guest:wrong@" + srv.Listener.Addr().String() + "?queue=created", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"rabbitmq://localhost", "rabbitmq://localhost?queue=a&minConsumers=x", "rabbitmq://localhost?queue=a&tls=maybe", "rabbitmq://localhost?queue=a&timeout=x"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
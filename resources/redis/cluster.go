@@ -0,0 +1,86 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// clusterSlots is the number of hash slots of a Redis Cluster
+const clusterSlots = 16384
+
+// Cluster waits for a cluster node to report cluster_state:ok with all hash slots assigned and served,
+// e.g. redis-cluster://:pass@node1:7000,node2:7001?tls=true. Nodes are asked in order until one is healthy.
+type Cluster struct {
+	*Redis
+	nodes []string
+}
+
+func newCluster(u *url.URL, r *Redis) (*Cluster, error) {
+	nodes, err := splitNodes(u.Host, "6379")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cluster{Redis: r, nodes: nodes}, nil
+}
+
+func (c *Cluster) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var errs []error
+
+	for _, node := range c.nodes {
+		err := c.testNode(ctx, node)
+
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", node, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Cluster) testNode(ctx context.Context, node string) error {
+	cl, err := c.open(ctx, node)
+
+	if err != nil {
+		return err
+	}
+
+	defer cl.Close()
+
+	reply, err := cl.do("CLUSTER", "INFO")
+
+	if err != nil {
+		return fmt.Errorf("cluster info: %w", err)
+	}
+
+	info, ok := reply.(string)
+
+	if !ok {
+		return fmt.Errorf("cluster info: unexpected reply %v", reply)
+	}
+
+	fields := parseInfo(info)
+
+	if state := fields["cluster_state"]; state != "ok" {
+		return fmt.Errorf("cluster state is %q", state)
+	}
+
+	if assigned, _ := strconv.Atoi(fields["cluster_slots_assigned"]); assigned != clusterSlots {
+		return fmt.Errorf("%d of %d slots assigned", assigned, clusterSlots)
+	}
+
+	if served, _ := strconv.Atoi(fields["cluster_slots_ok"]); served != clusterSlots {
+		return fmt.Errorf("%d of %d slots served", served, clusterSlots)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package redis
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCluster_Test(t *testing.T) {
+	healthy := serve(t, map[string]string{
+		"AUTH secret":  "+OK\r\n",
+		"CLUSTER INFO": bulk("cluster_state:ok\r\ncluster_slots_assigned:16384\r\ncluster_slots_ok:16384\r\ncluster_known_nodes:6\r\n"),
+	})
+	unassigned := serve(t, map[string]string{
+		"CLUSTER INFO": bulk("cluster_state:fail\r\ncluster_slots_assigned:10923\r\ncluster_slots_ok:10923\r\n"),
+	})
+	failing := serve(t, map[string]string{
+		"CLUSTER INFO": bulk("cluster_state:ok\r\ncluster_slots_assigned:16384\r\ncluster_slots_ok:10923\r\ncluster_slots_pfail:5461\r\n"),
+	})
+	standalone := serve(t, map[string]string{
+		"CLUSTER INFO": "-ERR This instance has cluster support disabled\r\n",
+	})
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"redis-cluster://" + healthy, true},
+		{"redis-cluster://:secret@" + healthy, true},
+		{"redis-cluster://" + unassigned, false},
+		{"redis-cluster://" + failing, false},
+		{"redis-cluster://" + standalone, false},
+		{"redis-cluster://" + unassigned + "," + healthy, true},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
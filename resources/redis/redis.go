@@ -0,0 +1,279 @@
+// Package redis provides resources checking Redis servers, clusters and sentinels
+package redis
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"github.com/go-waitfor/waitfor/internal/tlsconfig"
+)
+
+const (
+	Scheme         = "redis"
+	SecureScheme   = "rediss"
+	ClusterScheme  = "redis-cluster"
+	SentinelScheme = "redis-sentinel"
+
+	DefaultTimeout = 5 * time.Second
+
+	RoleMaster  = "master"
+	RoleReplica = "replica"
+)
+
+// Redis sends PING and optionally checks the replication role, the number of connected replicas
+// and existence of keys, e.g. redis://:pass@localhost:6379/0?role=master&minReplicas=1&key=ready
+type Redis struct {
+	addr        string
+	username    string
+	password    string
+	db          string
+	role        string
+	minReplicas int
+	keys        []string
+	timeout     time.Duration
+	tlsConfig   *tls.Config
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme, SecureScheme, ClusterScheme, SentinelScheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	r := &Redis{
+		addr: u.Host,
+		keys: q["key"],
+	}
+
+	if u.User != nil {
+		r.username = u.User.Username()
+		r.password, _ = u.User.Password()
+	}
+
+	var err error
+
+	if r.minReplicas, err = query.Int(q, "minReplicas", 0); err != nil {
+		return nil, err
+	}
+
+	if r.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	secure := u.Scheme == SecureScheme
+
+	// cluster and sentinel schemes have no secure variants and check no roles or keys
+	if u.Scheme == ClusterScheme || u.Scheme == SentinelScheme {
+		if secure, err = query.Bool(q, "tls", false); err != nil {
+			return nil, err
+		}
+
+		unsupported := []string{"role", "key"}
+
+		if u.Scheme == ClusterScheme {
+			unsupported = append(unsupported, "minReplicas")
+		}
+
+		for _, param := range unsupported {
+			if q.Has(param) {
+				return nil, fmt.Errorf("%q: not supported by %s: %w", param, u.Scheme, waitfor.ErrInvalidArgument)
+			}
+		}
+	}
+
+	if secure {
+		if r.tlsConfig, err = tlsconfig.FromQuery(q, &tls.Config{}); err != nil {
+			return nil, err
+		}
+	}
+
+	switch u.Scheme {
+	case ClusterScheme:
+		return newCluster(u, r)
+	case SentinelScheme:
+		return newSentinel(u, r)
+	}
+
+	if u.Port() == "" {
+		r.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+
+	if r.db = strings.Trim(u.Path, "/"); r.db != "" {
+		if _, err := strconv.Atoi(r.db); err != nil {
+			return nil, fmt.Errorf("%q: database must be a number: %w", "path", waitfor.ErrInvalidArgument)
+		}
+	}
+
+	switch role := q.Get("role"); role {
+	case "", RoleMaster, RoleReplica:
+		r.role = role
+	case "slave":
+		r.role = RoleReplica
+	default:
+		return nil, fmt.Errorf("%q: unknown role %q: %w", "role", role, waitfor.ErrInvalidArgument)
+	}
+
+	return r, nil
+}
+
+// splitNodes splits a comma separated list of nodes and adds a default port
+func splitNodes(hosts, port string) ([]string, error) {
+	var nodes []string
+
+	for _, node := range strings.Split(hosts, ",") {
+		if node == "" {
+			return nil, fmt.Errorf("%q: empty node: %w", "url", waitfor.ErrInvalidArgument)
+		}
+
+		if _, _, err := net.SplitHostPort(node); err != nil {
+			node = net.JoinHostPort(strings.Trim(node, "[]"), port)
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+func (r *Redis) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cl, err := r.open(ctx, r.addr)
+
+	if err != nil {
+		return err
+	}
+
+	defer cl.Close()
+
+	if r.db != "" {
+		if _, err := cl.do("SELECT", r.db); err != nil {
+			return fmt.Errorf("select: %w", err)
+		}
+	}
+
+	reply, err := cl.do("PING")
+
+	if err != nil {
+		return fmt.Errorf("ping: %w", err)
+	}
+
+	if reply != "PONG" {
+		return fmt.Errorf("ping: unexpected reply %v", reply)
+	}
+
+	if r.role != "" || r.minReplicas > 0 {
+		if err := r.testReplication(cl); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range r.keys {
+		reply, err := cl.do("EXISTS", key)
+
+		if err != nil {
+			return fmt.Errorf("exists: %w", err)
+		}
+
+		if reply != int64(1) {
+			return fmt.Errorf("key %q does not exist", key)
+		}
+	}
+
+	return nil
+}
+
+// testReplication checks the role and connected replicas reported by INFO
+func (r *Redis) testReplication(cl *conn) error {
+	reply, err := cl.do("INFO", "replication")
+
+	if err != nil {
+		return fmt.Errorf("info: %w", err)
+	}
+
+	info, ok := reply.(string)
+
+	if !ok {
+		return fmt.Errorf("info: unexpected reply %v", reply)
+	}
+
+	fields := parseInfo(info)
+	role := fields["role"]
+
+	if role == "slave" {
+		role = RoleReplica
+	}
+
+	if r.role != "" && role != r.role {
+		return fmt.Errorf("role is %q, expected %q", role, r.role)
+	}
+
+	if r.minReplicas > 0 {
+		replicas, _ := strconv.Atoi(fields["connected_slaves"])
+
+		if replicas < r.minReplicas {
+			return fmt.Errorf("%d connected replicas, expected at least %d", replicas, r.minReplicas)
+		}
+	}
+
+	return nil
+}
+
+// open connects to a server and authenticates if credentials are set
+func (r *Redis) open(ctx context.Context, addr string) (*conn, error) {
+	c, err := r.dial(ctx, addr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = c.SetDeadline(deadline)
+	}
+
+	cl := &conn{rw: bufio.NewReadWriter(bufio.NewReader(c), bufio.NewWriter(c)), Closer: c}
+
+	if r.password != "" {
+		args := []string{"AUTH", r.password}
+
+		if r.username != "" {
+			args = []string{"AUTH", r.username, r.password}
+		}
+
+		if _, err := cl.do(args...); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("auth: %w", err)
+		}
+	}
+
+	return cl, nil
+}
+
+func (r *Redis) dial(ctx context.Context, addr string) (net.Conn, error) {
+	if r.tlsConfig == nil {
+		var d net.Dialer
+
+		return d.DialContext(ctx, "tcp", addr)
+	}
+
+	d := tls.Dialer{Config: r.tlsConfig}
+
+	return d.DialContext(ctx, "tcp", addr)
+}
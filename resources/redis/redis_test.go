@@ -0,0 +1,130 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+// serve starts a fake server answering commands with canned replies
+func serve(t *testing.T, replies map[string]string) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			c, err := l.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer c.Close()
+
+				r := bufio.NewReader(c)
+
+				for {
+					reply, err := readReply(r)
+
+					if err != nil {
+						return
+					}
+
+					var args []string
+
+					for _, arg := range reply.([]interface{}) {
+						args = append(args, arg.(string))
+					}
+
+					out, ok := replies[strings.Join(args, " ")]
+
+					if !ok {
+						out = "-ERR unknown command\r\n"
+					}
+
+					fmt.Fprint(c, out)
+				}
+			}()
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func bulk(s string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(s), s)
+}
+
+func TestRedis_Test(t *testing.T) {
+	addr := serve(t, map[string]string{
+		"AUTH secret":       "+OK\r\n",
+		"SELECT 2":          "+OK\r\n",
+		"PING":              "+PONG\r\n",
+		"INFO replication":  bulk("# Replication\r\nrole:master\r\nconnected_slaves:1\r\n"),
+		"EXISTS ready":      ":1\r\n",
+		"EXISTS missing":    ":0\r\n",
+		"AUTH admin secret": "-WRONGPASS invalid username-password pair\r\n",
+	})
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"redis://" + addr, true},
+		{"redis://:secret@" + addr + "/2", true},
+		{"redis://" + addr + "?role=master&minReplicas=1", true},
+		{"redis://" + addr + "?role=replica", false},
+		{"redis://" + addr + "?minReplicas=2", false},
+		{"redis://" + addr + "?key=ready", true},
+		{"redis://" + addr + "?key=ready&key=missing", false},
+		{"redis://admin:secret@" + addr, false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"redis://localhost?role=leader", "redis://localhost/db", "redis://localhost?minReplicas=x", "rediss://localhost?ca=missing.pem", "redis-cluster://node1,,node2", "redis-cluster://node1?tls=maybe", "redis-sentinel://sentinel1", "redis-sentinel://sentinel1,/mymaster", "redis-cluster://node1?role=master", "redis-cluster://node1?key=ready", "redis-cluster://node1?minReplicas=1", "redis-sentinel://sentinel1/mymaster?role=replica", "redis-sentinel://sentinel1/mymaster?key=ready"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
+
+func TestReadReply_Limits(t *testing.T) {
+	for _, reply := range []string{"*2147483647\r\n", "*1025\r\n", "$2147483647\r\n"} {
+		_, err := readReply(bufio.NewReader(strings.NewReader(reply)))
+
+		assert.ErrorContains(t, err, "malformed", reply)
+	}
+
+	items, err := readReply(bufio.NewReader(strings.NewReader("*2\r\n+a\r\n:1\r\n")))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", int64(1)}, items)
+}
@@ -0,0 +1,129 @@
+package redis
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxBulkSize limits bulk replies read from a server
+	maxBulkSize = 1024 * 1024
+	// maxArraySize limits the number of elements of array replies read from a server
+	maxArraySize = 1024
+)
+
+// conn is a minimal RESP client sufficient for readiness commands
+type conn struct {
+	io.Closer
+	rw *bufio.ReadWriter
+}
+
+// do sends a command and reads its reply, server errors are returned as errors
+func (c *conn) do(args ...string) (interface{}, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+
+	if _, err := c.rw.WriteString(b.String()); err != nil {
+		return nil, err
+	}
+
+	if err := c.rw.Flush(); err != nil {
+		return nil, err
+	}
+
+	return readReply(c.rw.Reader)
+}
+
+// readReply decodes a single RESP2 reply
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) < 3 || !strings.HasSuffix(line, "\r\n") {
+		return nil, fmt.Errorf("malformed reply %q", line)
+	}
+
+	kind, payload := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, errors.New(payload)
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		size, err := strconv.Atoi(payload)
+
+		if err != nil || size > maxBulkSize {
+			return nil, fmt.Errorf("malformed bulk size %q", payload)
+		}
+
+		if size < 0 {
+			return nil, nil
+		}
+
+		buf := make([]byte, size+2)
+
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+
+		return string(buf[:size]), nil
+	case '*':
+		size, err := strconv.Atoi(payload)
+
+		if err != nil || size > maxArraySize {
+			return nil, fmt.Errorf("malformed array size %q", payload)
+		}
+
+		if size < 0 {
+			return nil, nil
+		}
+
+		items := make([]interface{}, 0, size)
+
+		for i := 0; i < size; i++ {
+			item, err := readReply(r)
+
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+
+		return items, nil
+	}
+
+	return nil, fmt.Errorf("unknown reply type %q", kind)
+}
+
+// parseInfo converts an INFO reply into key-value pairs
+func parseInfo(info string) map[string]string {
+	fields := make(map[string]string)
+
+	for _, line := range strings.Split(info, "\r\n") {
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		if k, v, ok := strings.Cut(line, ":"); ok {
+			fields[k] = v
+		}
+	}
+
+	return fields
+}
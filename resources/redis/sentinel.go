@@ -0,0 +1,110 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+// masterDown describes master flags which make it unavailable
+var masterDown = map[string]string{
+	"s_down":       "subjectively down",
+	"o_down":       "objectively down",
+	"disconnected": "disconnected",
+}
+
+// Sentinel waits for sentinels to know a master of a named service which is not down, optionally with
+// a minimum of replicas, e.g. redis-sentinel://sentinel1,sentinel2:26380/mymaster?minReplicas=1.
+// Sentinels are asked in order until one reports a healthy master.
+type Sentinel struct {
+	*Redis
+	sentinels []string
+	master    string
+}
+
+func newSentinel(u *url.URL, r *Redis) (*Sentinel, error) {
+	sentinels, err := splitNodes(u.Host, "26379")
+
+	if err != nil {
+		return nil, err
+	}
+
+	master := strings.Trim(u.Path, "/")
+
+	if master == "" {
+		return nil, fmt.Errorf("%q: path must be a master name: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	return &Sentinel{Redis: r, sentinels: sentinels, master: master}, nil
+}
+
+func (s *Sentinel) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var errs []error
+
+	for _, sentinel := range s.sentinels {
+		err := s.testSentinel(ctx, sentinel)
+
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", sentinel, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Sentinel) testSentinel(ctx context.Context, sentinel string) error {
+	cl, err := s.open(ctx, sentinel)
+
+	if err != nil {
+		return err
+	}
+
+	defer cl.Close()
+
+	reply, err := cl.do("SENTINEL", "MASTER", s.master)
+
+	if err != nil {
+		return fmt.Errorf("sentinel master: %w", err)
+	}
+
+	items, ok := reply.([]interface{})
+
+	if !ok || len(items)%2 != 0 {
+		return fmt.Errorf("sentinel master: unexpected reply %v", reply)
+	}
+
+	// the reply is a flat list of field names and values
+	fields := make(map[string]string, len(items)/2)
+
+	for i := 0; i < len(items); i += 2 {
+		k, _ := items[i].(string)
+		v, _ := items[i+1].(string)
+		fields[k] = v
+	}
+
+	for _, flag := range strings.Split(fields["flags"], ",") {
+		if state, ok := masterDown[flag]; ok {
+			return fmt.Errorf("master %s is %s", s.master, state)
+		}
+	}
+
+	if s.minReplicas > 0 {
+		replicas, _ := strconv.Atoi(fields["num-slaves"])
+
+		if replicas < s.minReplicas {
+			return fmt.Errorf("master %s has %d replicas, expected at least %d", s.master, replicas, s.minReplicas)
+		}
+	}
+
+	return nil
+}
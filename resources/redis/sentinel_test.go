@@ -0,0 +1,60 @@
+package redis
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pairs encodes a flat array of bulk strings
+func pairs(items ...string) string {
+	var b strings.Builder
+
+	b.WriteString("*" + strconv.Itoa(len(items)) + "\r\n")
+
+	for _, item := range items {
+		b.WriteString(bulk(item))
+	}
+
+	return b.String()
+}
+
+func TestSentinel_Test(t *testing.T) {
+	addr := serve(t, map[string]string{
+		"SENTINEL MASTER mymaster": pairs("name", "mymaster", "ip", "10.0.0.5", "port", "6379", "flags", "master", "num-slaves", "2"),
+		"SENTINEL MASTER cache":    pairs("name", "cache", "ip", "10.0.0.6", "port", "6379", "flags", "master,o_down", "num-slaves", "1"),
+		"SENTINEL MASTER sessions": pairs("name", "sessions", "ip", "10.0.0.7", "port", "6379", "flags", "master,s_down,disconnected", "num-slaves", "0"),
+		"SENTINEL MASTER unknown":  "-ERR No such master with that name\r\n",
+	})
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"redis-sentinel://" + addr + "/mymaster", true},
+		{"redis-sentinel://" + addr + "/mymaster?minReplicas=2", true},
+		{"redis-sentinel://" + addr + "/mymaster?minReplicas=3", false},
+		{"redis-sentinel://" + addr + "/cache", false},
+		{"redis-sentinel://" + addr + "/sessions", false},
+		{"redis-sentinel://" + addr + "/unknown", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
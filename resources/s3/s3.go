@@ -0,0 +1,95 @@
+// Package s3 provides a resource checking S3 compatible buckets and objects
+package s3
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/awsconfig"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+const (
+	Scheme = "s3"
+
+	DefaultTimeout = 5 * time.Second
+)
+
+// S3 waits for a bucket or, when the path is set, for an object to exist. Credentials are
+// resolved with the default AWS chain, e.g. s3://reports/2024/summary.csv?region=eu-west-1
+// or s3://data?endpoint=http://localhost:9000&pathStyle=true for MinIO
+type S3 struct {
+	bucket    string
+	key       string
+	aws       awsconfig.Params
+	pathStyle bool
+	timeout   time.Duration
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("%q: missing bucket: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	s := &S3{
+		bucket: u.Host,
+		key:    strings.TrimPrefix(u.Path, "/"),
+	}
+
+	var err error
+
+	if s.aws, err = awsconfig.FromQuery(q); err != nil {
+		return nil, err
+	}
+
+	if s.pathStyle, err = query.Bool(q, "pathStyle", false); err != nil {
+		return nil, err
+	}
+
+	if s.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *S3) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	cfg, err := s.aws.Load(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = s.pathStyle
+	})
+
+	if s.key == "" {
+		_, err = client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(s.bucket)})
+	} else {
+		_, err = client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(s.key)})
+	}
+
+	return err
+}
@@ -0,0 +1,59 @@
+// Package serial provides a resource checking serial devices
+package serial
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+const (
+	Scheme = "serial"
+
+	DefaultBaudRate = 9600
+)
+
+// Serial waits for a serial device to exist and to be configurable at a baud rate,
+// e.g. serial:///dev/ttyUSB0?baud=115200. It is supported on Linux only.
+type Serial struct {
+	path string
+	baud int
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	s := &Serial{path: u.Host + u.Path}
+
+	if s.path == "" {
+		return nil, fmt.Errorf("%q: %w", "path", waitfor.ErrInvalidArgument)
+	}
+
+	var err error
+
+	if s.baud, err = query.Int(u.Query(), "baud", DefaultBaudRate); err != nil {
+		return nil, err
+	}
+
+	if !supported(s.baud) {
+		return nil, fmt.Errorf("%q: unsupported baud rate %d: %w", "baud", s.baud, waitfor.ErrInvalidArgument)
+	}
+
+	return s, nil
+}
+
+func (s *Serial) Test(_ context.Context) error {
+	return configure(s.path, s.baud)
+}
@@ -0,0 +1,59 @@
+package serial
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+var baudRates = map[int]uint32{
+	1200:    unix.B1200,
+	2400:    unix.B2400,
+	4800:    unix.B4800,
+	9600:    unix.B9600,
+	19200:   unix.B19200,
+	38400:   unix.B38400,
+	57600:   unix.B57600,
+	115200:  unix.B115200,
+	230400:  unix.B230400,
+	460800:  unix.B460800,
+	921600:  unix.B921600,
+	1000000: unix.B1000000,
+	2000000: unix.B2000000,
+	4000000: unix.B4000000,
+}
+
+func supported(baud int) bool {
+	_, ok := baudRates[baud]
+	return ok
+}
+
+// configure opens a device without making it the controlling terminal and sets the baud rate
+func configure(path string, baud int) error {
+	f, err := os.OpenFile(path, os.O_RDWR|unix.O_NOCTTY|unix.O_NONBLOCK, 0)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	fd := int(f.Fd())
+	t, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+
+	if err != nil {
+		return fmt.Errorf("%s is not a terminal device: %w", path, err)
+	}
+
+	speed := baudRates[baud]
+	t.Cflag = t.Cflag&^unix.CBAUD | speed
+	t.Ispeed = speed
+	t.Ospeed = speed
+
+	if err := unix.IoctlSetTermios(fd, unix.TCSETS, t); err != nil {
+		return fmt.Errorf("%s: set baud rate %d: %w", path, baud, err)
+	}
+
+	return nil
+}
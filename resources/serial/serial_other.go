@@ -0,0 +1,13 @@
+//go:build !linux
+
+package serial
+
+import "github.com/go-waitfor/waitfor"
+
+func supported(_ int) bool {
+	return true
+}
+
+func configure(_ string, _ int) error {
+	return waitfor.ErrNotSupported
+}
@@ -0,0 +1,67 @@
+package serial
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/creack/pty"
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerial_Test(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("serial devices are supported on linux only")
+	}
+
+	// a pseudo terminal accepts the same settings as a serial device
+	ptmx, tty, err := pty.Open()
+
+	assert.NoError(t, err)
+
+	defer ptmx.Close()
+	defer tty.Close()
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"serial://" + tty.Name(), true},
+		{"serial://" + tty.Name() + "?baud=115200", true},
+		{"serial://" + filepath.Join(t.TempDir(), "ttyUSB0"), false},
+		{"serial:///dev/null", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	locations := []string{"serial://", "serial:///dev/ttyUSB0?baud=fast"}
+
+	if runtime.GOOS == "linux" {
+		locations = append(locations, "serial:///dev/ttyUSB0?baud=12345")
+	}
+
+	for _, location := range locations {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
@@ -0,0 +1,98 @@
+// Package sql provides a resource checking any database with a registered database/sql driver
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"fmt"
+	"net/url"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+const Scheme = "sql"
+
+// SQL pings a database and optionally runs a validation query comparing the first column of the first row,
+// e.g. sql://postgres?dsn=postgres://user:pass@db/app&query=SELECT+count(*)+FROM+users&expect=1.
+// The driver must be registered by the caller, e.g. by importing github.com/lib/pq.
+type SQL struct {
+	driver string
+	dsn    string
+	query  string
+	expect *string
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("%q: %w", "driver", waitfor.ErrInvalidArgument)
+	}
+
+	if q.Get("dsn") == "" {
+		return nil, fmt.Errorf("%q: %w", "dsn", waitfor.ErrInvalidArgument)
+	}
+
+	s := &SQL{
+		driver: u.Host,
+		dsn:    q.Get("dsn"),
+		query:  q.Get("query"),
+	}
+
+	if q.Has("expect") {
+		if s.query == "" {
+			return nil, fmt.Errorf("%q: requires query: %w", "expect", waitfor.ErrInvalidArgument)
+		}
+
+		expect := q.Get("expect")
+		s.expect = &expect
+	}
+
+	return s, nil
+}
+
+func (s *SQL) Test(ctx context.Context) error {
+	db, err := dbsql.Open(s.driver, s.dsn)
+
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	if err := db.PingContext(ctx); err != nil {
+		return err
+	}
+
+	if s.query == "" {
+		return nil
+	}
+
+	return Validate(ctx, db, s.query, s.expect)
+}
+
+// Validate runs a query and compares the first column of the first row with an expected value if it is set
+func Validate(ctx context.Context, db *dbsql.DB, query string, expect *string) error {
+	var value dbsql.NullString
+
+	if err := db.QueryRowContext(ctx, query).Scan(&value); err != nil {
+		return err
+	}
+
+	if expect != nil && value.String != *expect {
+		return fmt.Errorf("query returned %q, expected %q", value.String, *expect)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDriver accepts "up" dsn and returns the query text as a single value
+type (
+	fakeDriver struct{}
+	fakeConn   struct{}
+	fakeStmt   struct{ query string }
+	fakeRows   struct {
+		value string
+		done  bool
+	}
+)
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	if dsn != "up" {
+		return nil, errors.New("connection refused")
+	}
+
+	return fakeConn{}, nil
+}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return &fakeStmt{query}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return 0 }
+func (s *fakeStmt) Exec(_ []driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (s *fakeStmt) Query(_ []driver.Value) (driver.Rows, error) {
+	return &fakeRows{value: s.query}, nil
+}
+
+func (r *fakeRows) Columns() []string { return []string{"value"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+
+	r.done = true
+	dest[0] = r.value
+
+	return nil
+}
+
+func init() {
+	dbsql.Register("fake", fakeDriver{})
+}
+
+func TestSQL_Test(t *testing.T) {
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"sql://fake?dsn=up", true},
+		{"sql://fake?dsn=down", false},
+		{"sql://fake?dsn=up&query=ready", true},
+		{"sql://fake?dsn=up&query=ready&expect=ready", true},
+		{"sql://fake?dsn=up&query=starting&expect=ready", false},
+		{"sql://unknown?dsn=up", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"sql://fake", "sql://?dsn=up", "sql://fake?dsn=up&expect=1"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
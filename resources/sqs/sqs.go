@@ -0,0 +1,133 @@
+// Package sqs provides a resource checking AWS SQS queues
+package sqs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/awsconfig"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+const (
+	Scheme = "sqs"
+
+	DefaultTimeout = 5 * time.Second
+)
+
+// SQS waits for a queue to exist and optionally for its approximate number of visible messages
+// to be within limits, e.g. sqs://orders?minMessages=1&region=eu-west-1
+// or sqs://orders?maxMessages=0&endpoint=http://localhost:4566 for LocalStack.
+// Queues owned by another account are looked up with accountId.
+type SQS struct {
+	queue       string
+	owner       string
+	aws         awsconfig.Params
+	minMessages int
+	maxMessages int
+	timeout     time.Duration
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Host == "" || strings.Trim(u.Path, "/") != "" {
+		return nil, fmt.Errorf("%q: host must be a queue name: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	s := &SQS{
+		queue: u.Host,
+		owner: q.Get("accountId"),
+	}
+
+	var err error
+
+	if s.aws, err = awsconfig.FromQuery(q); err != nil {
+		return nil, err
+	}
+
+	if s.minMessages, err = query.Int(q, "minMessages", 0); err != nil {
+		return nil, err
+	}
+
+	if s.maxMessages, err = query.Int(q, "maxMessages", -1); err != nil {
+		return nil, err
+	}
+
+	if s.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *SQS) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	cfg, err := s.aws.Load(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	client := sqs.NewFromConfig(cfg)
+	input := &sqs.GetQueueUrlInput{QueueName: aws.String(s.queue)}
+
+	if s.owner != "" {
+		input.QueueOwnerAWSAccountId = aws.String(s.owner)
+	}
+
+	queue, err := client.GetQueueUrl(ctx, input)
+
+	if err != nil {
+		return err
+	}
+
+	if s.minMessages <= 0 && s.maxMessages < 0 {
+		return nil
+	}
+
+	attrs, err := client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       queue.QueueUrl,
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameApproximateNumberOfMessages},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	count, err := strconv.Atoi(attrs.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessages)])
+
+	if err != nil {
+		return fmt.Errorf("approximate number of messages: %w", err)
+	}
+
+	if count < s.minMessages {
+		return fmt.Errorf("%d visible messages, expected at least %d", count, s.minMessages)
+	}
+
+	if s.maxMessages >= 0 && count > s.maxMessages {
+		return fmt.Errorf("%d visible messages, expected at most %d", count, s.maxMessages)
+	}
+
+	return nil
+}
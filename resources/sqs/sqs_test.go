@@ -0,0 +1,91 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQS_Test(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_CONFIG_FILE", "/dev/null")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/dev/null")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+	// the fake server knows the orders queue with 3 visible messages
+	var srv *httptest.Server
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "AmazonSQS.GetQueueUrl":
+			if body["QueueName"] != "orders" {
+				w.Header().Set("x-amzn-query-error", "AWS.SimpleQueueService.NonExistentQueue;Sender")
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type":"com.amazonaws.sqs#QueueDoesNotExist","message":"The specified queue does not exist."}`))
+
+				return
+			}
+
+			_ = json.NewEncoder(w).Encode(map[string]string{"QueueUrl": srv.URL + "/000000000000/orders"})
+		case "AmazonSQS.GetQueueAttributes":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"Attributes": map[string]string{"ApproximateNumberOfMessages": "3"},
+			})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	endpoint := "endpoint=" + url.QueryEscape(srv.URL)
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"sqs://orders?" + endpoint, true},
+		{"sqs://payments?" + endpoint, false},
+		{"sqs://orders?minMessages=3&" + endpoint, true},
+		{"sqs://orders?minMessages=4&" + endpoint, false},
+		{"sqs://orders?maxMessages=3&" + endpoint, true},
+		{"sqs://orders?maxMessages=0&" + endpoint, false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"sqs://", "sqs://orders/extra", "sqs://orders?endpoint=localhost", "sqs://orders?minMessages=x", "sqs://orders?maxMessages=x", "sqs://orders?timeout=x"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
@@ -0,0 +1,114 @@
+// Package srv provides a resource expanding DNS SRV records into TCP checks
+package srv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/dnsconfig"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+const (
+	Scheme = "dns+srv"
+
+	DefaultTimeout = 5 * time.Second
+)
+
+// SRV resolves a SRV record and dials every returned target, e.g. dns+srv://_postgres._tcp.db.service.consul.
+// All targets must accept connections unless a minimum is set, e.g. ?min=2.
+// Names are resolved with the system resolver or a given name server, e.g. ?nameserver=127.0.0.1:8600.
+type SRV struct {
+	name     string
+	min      int
+	resolver *net.Resolver
+	timeout  time.Duration
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("%q: missing record name: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	s := &SRV{
+		name:     u.Host,
+		resolver: dnsconfig.FromQuery(q),
+	}
+
+	var err error
+
+	if s.min, err = query.Int(q, "min", 0); err != nil {
+		return nil, err
+	}
+
+	if s.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *SRV) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	_, records, err := s.resolver.LookupSRV(ctx, "", "", s.name)
+
+	if err != nil {
+		return fmt.Errorf("dns: %s does not resolve: %w", s.name, err)
+	}
+
+	// a single dot target means the service is explicitly not available
+	if len(records) == 1 && records[0].Target == "." {
+		return fmt.Errorf("dns: %s has no targets", s.name)
+	}
+
+	var errs []error
+
+	for _, r := range records {
+		var d net.Dialer
+
+		addr := net.JoinHostPort(strings.TrimSuffix(r.Target, "."), strconv.Itoa(int(r.Port)))
+		conn, err := d.DialContext(ctx, "tcp", addr)
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		_ = conn.Close()
+	}
+
+	required := s.min
+
+	if required <= 0 {
+		required = len(records)
+	}
+
+	if ready := len(records) - len(errs); ready < required {
+		err := fmt.Errorf("%d of %d targets ready, expected at least %d", ready, len(records), required)
+
+		return errors.Join(append([]error{err}, errs...)...)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package srv
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// serveDNS starts a fake name server answering SRV queries with targets on localhost
+func serveDNS(t *testing.T, records map[string][]uint16) string {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	t.Cleanup(func() { pc.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+
+		for {
+			n, addr, err := pc.ReadFrom(buf)
+
+			if err != nil {
+				return
+			}
+
+			var req dnsmessage.Message
+
+			if err := req.Unpack(buf[:n]); err != nil || len(req.Questions) != 1 {
+				continue
+			}
+
+			q := req.Questions[0]
+			res := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: req.ID, Response: true, Authoritative: true},
+				Questions: req.Questions,
+			}
+
+			ports, ok := records[q.Name.String()]
+
+			if !ok || q.Type != dnsmessage.TypeSRV {
+				res.RCode = dnsmessage.RCodeNameError
+			}
+
+			for _, port := range ports {
+				res.Answers = append(res.Answers, dnsmessage.Resource{
+					Header: dnsmessage.ResourceHeader{Name: q.Name, Type: dnsmessage.TypeSRV, Class: dnsmessage.ClassINET, TTL: 30},
+					Body:   &dnsmessage.SRVResource{Port: port, Target: dnsmessage.MustNewName("localhost.")},
+				})
+			}
+
+			out, err := res.Pack()
+
+			if err == nil {
+				_, _ = pc.WriteTo(out, addr)
+			}
+		}
+	}()
+
+	return pc.LocalAddr().String()
+}
+
+// listen returns ports of an open and a closed listener
+func listen(t *testing.T) (uint16, uint16) {
+	open, err := net.Listen("tcp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	t.Cleanup(func() { open.Close() })
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	closed.Close()
+
+	return uint16(open.Addr().(*net.TCPAddr).Port), uint16(closed.Addr().(*net.TCPAddr).Port)
+}
+
+func TestSRV_Test(t *testing.T) {
+	open, closed := listen(t)
+	nameserver := serveDNS(t, map[string][]uint16{
+		"_db._tcp.ready.test.":    {open, open},
+		"_db._tcp.degraded.test.": {open, closed},
+	})
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"dns+srv://_db._tcp.ready.test", true},
+		{"dns+srv://_db._tcp.degraded.test", false},
+		{"dns+srv://_db._tcp.degraded.test?min=1", true},
+		{"dns+srv://_db._tcp.degraded.test?min=2", false},
+		{"dns+srv://_db._tcp.ready.test?min=3", false},
+		{"dns+srv://_db._tcp.missing.test", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		q := u.Query()
+		q.Set("nameserver", nameserver)
+		u.RawQuery = q.Encode()
+
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"dns+srv://", "dns+srv://_db._tcp.example.com?min=x", "dns+srv://_db._tcp.example.com?timeout=x"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
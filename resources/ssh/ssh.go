@@ -0,0 +1,124 @@
+// Package ssh provides a resource checking SSH servers
+package ssh
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"github.com/go-waitfor/waitfor/internal/sshconfig"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	Scheme = "ssh"
+
+	DefaultTimeout = 5 * time.Second
+)
+
+// SSH reads the server identification and, when a password or a key is set, authenticates,
+// e.g. ssh://localhost:22 or ssh://deploy@localhost?key=/keys/id_ed25519&insecure=true
+type SSH struct {
+	addr    string
+	timeout time.Duration
+	config  *ssh.ClientConfig
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	s := &SSH{addr: u.Host}
+
+	if u.Port() == "" {
+		s.addr = net.JoinHostPort(u.Hostname(), "22")
+	}
+
+	var err error
+
+	if s.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	_, hasPassword := u.User.Password()
+
+	if hasPassword || q.Has("key") {
+		if s.config, err = sshconfig.FromURL(u, s.timeout); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func (s *SSH) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var d net.Dialer
+
+	conn, err := d.DialContext(ctx, "tcp", s.addr)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	_ = conn.SetDeadline(deadline)
+
+	if s.config == nil {
+		return readVersion(conn)
+	}
+
+	c, chans, reqs, err := ssh.NewClientConn(conn, s.addr, s.config)
+
+	if err != nil {
+		return err
+	}
+
+	return ssh.NewClient(c, chans, reqs).Close()
+}
+
+// readVersion reads the identification string, servers may send other lines before it
+func readVersion(conn net.Conn) error {
+	r := bufio.NewReader(conn)
+
+	for i := 0; i < 16; i++ {
+		line, err := r.ReadString('\n')
+
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(line, "SSH-") {
+			if !strings.HasPrefix(line, "SSH-2.0-") && !strings.HasPrefix(line, "SSH-1.99-") {
+				return fmt.Errorf("unsupported protocol %q", strings.TrimSpace(line))
+			}
+
+			// the client identification completes the exchange
+			_, err := conn.Write([]byte("SSH-2.0-waitfor\r\n"))
+
+			return err
+		}
+	}
+
+	return errors.New("no identification string")
+}
@@ -0,0 +1,69 @@
+// Package tcp provides a resource testing TCP reachability of a host
+package tcp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+const (
+	Scheme  = "tcp"
+	Scheme4 = "tcp4"
+	Scheme6 = "tcp6"
+
+	DefaultTimeout = 5 * time.Second
+)
+
+// TCP dials host:port, e.g. tcp://localhost:5432?timeout=3s
+type TCP struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme, Scheme4, Scheme6},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Hostname() == "" || u.Port() == "" {
+		return nil, fmt.Errorf("%q: host and port are required: %w", u.Host, waitfor.ErrInvalidArgument)
+	}
+
+	timeout, err := query.Duration(u.Query(), "timeout", DefaultTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &TCP{
+		network: u.Scheme,
+		address: u.Host,
+		timeout: timeout,
+	}, nil
+}
+
+func (t *TCP) Test(ctx context.Context) error {
+	d := net.Dialer{Timeout: t.timeout}
+
+	conn, err := d.DialContext(ctx, t.network, t.address)
+
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
@@ -0,0 +1,38 @@
+package tcp
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTCP_Test(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	defer l.Close()
+
+	u, _ := url.Parse("tcp://" + l.Addr().String() + "?timeout=1s")
+	rsc, err := New(u)
+
+	assert.NoError(t, err)
+	assert.NoError(t, rsc.Test(context.Background()))
+
+	l.Close()
+
+	assert.Error(t, rsc.Test(context.Background()))
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"tcp://localhost", "tcp://localhost:80?timeout=abc"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
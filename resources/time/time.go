@@ -0,0 +1,133 @@
+// Package time provides a resource waiting for a wall-clock time or a cron schedule
+package time
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"github.com/robfig/cron/v3"
+)
+
+const Scheme = "time"
+
+// now is replaced in tests
+var now = time.Now
+
+// Time blocks until a timestamp, e.g. time://?at=2025-01-01T02:00:00Z, a duration after the wait started,
+// e.g. time://?after=10m, or the next match of a cron expression, e.g. time://?cron=0 2 * * *&tz=Europe/Berlin.
+// With a window cron matches open a maintenance window instead, e.g. time://?cron=0 2 * * *&window=2h
+// is available from 02:00 to 04:00 and otherwise blocks until the next window opens.
+type Time struct {
+	at       time.Time
+	schedule cron.Schedule
+	location *time.Location
+	window   time.Duration
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	t := &Time{location: time.Local}
+
+	var set int
+
+	for _, param := range []string{"at", "after", "cron"} {
+		if q.Has(param) {
+			set++
+		}
+	}
+
+	if set != 1 {
+		return nil, fmt.Errorf("%q: exactly one of at, after or cron is required: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if tz := q.Get("tz"); tz != "" {
+		location, err := time.LoadLocation(tz)
+
+		if err != nil {
+			return nil, fmt.Errorf("%q: %s: %w", "tz", err, waitfor.ErrInvalidArgument)
+		}
+
+		t.location = location
+	}
+
+	var err error
+
+	if t.window, err = query.Duration(q, "window", 0); err != nil {
+		return nil, err
+	}
+
+	if t.window != 0 && !q.Has("cron") {
+		return nil, fmt.Errorf("%q: requires cron: %w", "window", waitfor.ErrInvalidArgument)
+	}
+
+	switch {
+	case q.Has("at"):
+		if t.at, err = time.ParseInLocation(time.RFC3339, q.Get("at"), t.location); err != nil {
+			return nil, fmt.Errorf("%q: %s: %w", "at", err, waitfor.ErrInvalidArgument)
+		}
+	case q.Has("after"):
+		after, err := query.Duration(q, "after", 0)
+
+		if err != nil {
+			return nil, err
+		}
+
+		t.at = now().Add(after)
+	default:
+		if t.schedule, err = cron.ParseStandard(q.Get("cron")); err != nil {
+			return nil, fmt.Errorf("%q: %s: %w", "cron", err, waitfor.ErrInvalidArgument)
+		}
+
+		// without a window the resource waits for the first match after its creation
+		if t.window == 0 {
+			t.at = t.schedule.Next(now().In(t.location))
+		}
+	}
+
+	return t, nil
+}
+
+func (t *Time) Test(ctx context.Context) error {
+	target := t.at
+
+	// the window is open if a match happened within its duration
+	if t.window != 0 {
+		target = t.schedule.Next(now().In(t.location).Add(-t.window))
+	}
+
+	// schedules like 0 0 30 2 * have no matches
+	if target.IsZero() {
+		return fmt.Errorf("cron expression never matches: %w", waitfor.ErrPermanent)
+	}
+
+	wait := target.Sub(now())
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%s remaining until %s: %w", wait.Round(time.Second), target.Format(time.RFC3339), ctx.Err())
+	}
+}
@@ -0,0 +1,85 @@
+package time
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTime_Test(t *testing.T) {
+	// 02:30 on a Wednesday
+	clock := time.Date(2025, 6, 4, 2, 30, 0, 0, time.UTC)
+	now = func() time.Time { return clock }
+
+	t.Cleanup(func() {
+		now = time.Now
+	})
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"time://?at=2025-06-04T02:00:00Z", true},
+		{"time://?at=2025-06-04T03:00:00Z", false},
+		{"time://?at=2025-06-04T04:00:00%2B02:00", true},
+		{"time://?after=0s", true},
+		{"time://?after=1h", false},
+		{"time://?cron=0+2+*+*+*&window=1h&tz=UTC", true},
+		{"time://?cron=0+2+*+*+*&window=10m&tz=UTC", false},
+		{"time://?cron=0+3+*+*+*&window=1h&tz=UTC", false},
+		{"time://?cron=0+2+*+*+*&window=1h&tz=Asia/Tokyo", false},
+		{"time://?cron=0+2+*+*+*&tz=UTC", false},
+		{"time://?cron=0+0+30+2+*", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		err = rsc.Test(ctx)
+		cancel()
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestTime_Test_Wait(t *testing.T) {
+	u, _ := url.Parse("time://?after=50ms")
+	rsc, err := New(u)
+
+	assert.NoError(t, err)
+
+	start := time.Now()
+
+	assert.NoError(t, rsc.Test(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{
+		"time://",
+		"time://?at=2025-06-04T02:00:00Z&after=1h",
+		"time://?at=tomorrow",
+		"time://?after=x",
+		"time://?cron=0+2+*+*",
+		"time://?cron=0+2+*+*+*&tz=Mars/Olympus",
+		"time://?cron=0+2+*+*+*&window=x",
+		"time://?after=1h&window=1h",
+	} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
@@ -0,0 +1,141 @@
+// Package tls provides a resource validating a TLS certificate of a host
+package tls
+
+import (
+	"context"
+	cryptotls "crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"github.com/go-waitfor/waitfor/internal/tlsconfig"
+)
+
+const (
+	Scheme = "tls"
+
+	DefaultTimeout = 5 * time.Second
+)
+
+// TLS completes a handshake and verifies the certificate chain, the host name
+// and optionally the number of days until the certificates expire,
+// e.g. tls://example.com:443?minDaysLeft=7&serverName=www.example.com
+type TLS struct {
+	address     string
+	timeout     time.Duration
+	minDaysLeft int
+	config      *cryptotls.Config
+}
+
+type (
+	options struct {
+		tls *cryptotls.Config
+	}
+
+	Option func(opts *options)
+)
+
+// Set a custom TLS config, url options override it per resource
+func WithTLSConfig(cfg *cryptotls.Config) Option {
+	return func(opts *options) {
+		opts.tls = cfg
+	}
+}
+
+// Use returns the resource config, options are applied to every resource
+func Use(setters ...Option) waitfor.ResourceConfig {
+	opts := &options{}
+
+	for _, setter := range setters {
+		setter(opts)
+	}
+
+	return waitfor.ResourceConfig{
+		Scheme: []string{Scheme},
+		Factory: func(u *url.URL) (waitfor.Resource, error) {
+			return newTLS(u, opts)
+		},
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	return newTLS(u, &options{})
+}
+
+func newTLS(u *url.URL, opts *options) (*TLS, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Hostname() == "" || u.Port() == "" {
+		return nil, fmt.Errorf("%q: host and port are required: %w", u.Host, waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	timeout, err := query.Duration(q, "timeout", DefaultTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	minDaysLeft, err := query.Int(q, "minDaysLeft", 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := tlsconfig.FromQuery(q, opts.tls)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg == nil {
+		cfg = &cryptotls.Config{}
+	} else {
+		cfg = cfg.Clone()
+	}
+
+	if cfg.ServerName == "" {
+		cfg.ServerName = u.Hostname()
+	}
+
+	return &TLS{
+		address:     u.Host,
+		timeout:     timeout,
+		minDaysLeft: minDaysLeft,
+		config:      cfg,
+	}, nil
+}
+
+func (t *TLS) Test(ctx context.Context) error {
+	d := cryptotls.Dialer{
+		NetDialer: &net.Dialer{Timeout: t.timeout},
+		Config:    t.config,
+	}
+
+	conn, err := d.DialContext(ctx, "tcp", t.address)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	if t.minDaysLeft <= 0 {
+		return nil
+	}
+
+	deadline := time.Now().Add(time.Duration(t.minDaysLeft) * 24 * time.Hour)
+
+	for _, cert := range conn.(*cryptotls.Conn).ConnectionState().PeerCertificates {
+		if cert.NotAfter.Before(deadline) {
+			return fmt.Errorf("certificate %q expires at %s", cert.Subject.CommonName, cert.NotAfter.Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
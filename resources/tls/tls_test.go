@@ -0,0 +1,41 @@
+package tls
+
+import (
+	"context"
+	nethttp "net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTLS_Test(t *testing.T) {
+	srv := httptest.NewTLSServer(nil)
+	defer srv.Close()
+
+	cfg := srv.Client().Transport.(*nethttp.Transport).TLSClientConfig
+	addr := srv.Listener.Addr().String()
+
+	u, _ := url.Parse("tls://" + addr)
+	rsc, err := New(u)
+
+	assert.NoError(t, err)
+	assert.Error(t, rsc.Test(context.Background()), "unknown authority")
+
+	u, _ = url.Parse("tls://" + addr + "?serverName=example.com&minDaysLeft=7")
+	rsc, err = Use(WithTLSConfig(cfg)).Factory(u)
+
+	assert.NoError(t, err)
+	assert.NoError(t, rsc.Test(context.Background()))
+
+	u, _ = url.Parse("tls://" + addr + "?serverName=example.com&minDaysLeft=100000")
+	rsc, _ = Use(WithTLSConfig(cfg)).Factory(u)
+
+	assert.Error(t, rsc.Test(context.Background()), "expiring")
+
+	u, _ = url.Parse("tls://" + addr + "?serverName=other.org")
+	rsc, _ = Use(WithTLSConfig(cfg)).Factory(u)
+
+	assert.Error(t, rsc.Test(context.Background()), "host name mismatch")
+}
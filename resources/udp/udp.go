@@ -0,0 +1,138 @@
+// Package udp provides a resource sending a probe datagram to a host
+package udp
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+const (
+	Scheme  = "udp"
+	Scheme4 = "udp4"
+	Scheme6 = "udp6"
+
+	DefaultTimeout = 5 * time.Second
+
+	// refusalWindow is how long to wait for an ICMP port unreachable when no response is expected
+	refusalWindow = 200 * time.Millisecond
+)
+
+// UDP sends a probe datagram and optionally validates the response,
+// e.g. udp://localhost:53?payloadHex=...&response=true or udp://localhost:514?payload=ping&expect=pong
+type UDP struct {
+	network  string
+	address  string
+	timeout  time.Duration
+	payload  []byte
+	expect   []byte
+	response bool
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme, Scheme4, Scheme6},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	if u.Hostname() == "" || u.Port() == "" {
+		return nil, fmt.Errorf("%q: host and port are required: %w", u.Host, waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	timeout, err := query.Duration(q, "timeout", DefaultTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	payload := []byte(q.Get("payload"))
+
+	if value := q.Get("payloadHex"); value != "" {
+		if payload, err = hex.DecodeString(value); err != nil {
+			return nil, fmt.Errorf("%q: %s: %w", "payloadHex", err, waitfor.ErrInvalidArgument)
+		}
+	}
+
+	expect := []byte(q.Get("expect"))
+	response, err := query.Bool(q, "response", len(expect) > 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &UDP{
+		network:  u.Scheme,
+		address:  u.Host,
+		timeout:  timeout,
+		payload:  payload,
+		expect:   expect,
+		response: response,
+	}, nil
+}
+
+func (u *UDP) Test(ctx context.Context) error {
+	d := net.Dialer{Timeout: u.timeout}
+
+	conn, err := d.DialContext(ctx, u.network, u.address)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	if _, err := conn.Write(u.payload); err != nil {
+		return err
+	}
+
+	wait := refusalWindow
+
+	if u.response {
+		wait = u.timeout
+	}
+
+	deadline := time.Now().Add(wait)
+
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 64*1024)
+	n, err := conn.Read(buf)
+
+	if err != nil {
+		var netErr net.Error
+
+		// silence means the datagram was not rejected
+		if !u.response && errors.As(err, &netErr) && netErr.Timeout() {
+			return nil
+		}
+
+		return err
+	}
+
+	if len(u.expect) > 0 && !bytes.Contains(buf[:n], u.expect) {
+		return fmt.Errorf("unexpected response: %q", buf[:n])
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package udp
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUDP_Test(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	defer conn.Close()
+
+	go func() {
+		buf := make([]byte, 1024)
+
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+
+			if err != nil {
+				return
+			}
+
+			if string(buf[:n]) == "ping" {
+				_, _ = conn.WriteTo([]byte("pong"), addr)
+			} else {
+				_, _ = conn.WriteTo([]byte("nope"), addr)
+			}
+		}
+	}()
+
+	u, _ := url.Parse("udp://" + conn.LocalAddr().String() + "?payload=ping&expect=pong&timeout=1s")
+	rsc, err := New(u)
+
+	assert.NoError(t, err)
+	assert.NoError(t, rsc.Test(context.Background()))
+
+	u, _ = url.Parse("udp://" + conn.LocalAddr().String() + "?payload=hello&expect=pong&timeout=1s")
+	rsc, _ = New(u)
+
+	assert.Error(t, rsc.Test(context.Background()))
+}
+
+func TestUDP_Test_Refused(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	address := conn.LocalAddr().String()
+	conn.Close()
+
+	u, _ := url.Parse("udp://" + address)
+	rsc, err := New(u)
+
+	assert.NoError(t, err)
+	assert.Error(t, rsc.Test(context.Background()))
+}
@@ -0,0 +1,17 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+
+package unix
+
+import (
+	"io/fs"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+func checkOwner(_ fs.FileInfo, uid, gid int) error {
+	if uid >= 0 || gid >= 0 {
+		return waitfor.ErrNotSupported
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package unix
+
+import (
+	"fmt"
+	"io/fs"
+	"syscall"
+)
+
+// checkOwner checks the file owner, negative ids are not checked
+func checkOwner(info fs.FileInfo, uid, gid int) error {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+
+	if !ok {
+		return nil
+	}
+
+	if uid >= 0 && int(stat.Uid) != uid {
+		return fmt.Errorf("%s: owner %d is not %d", info.Name(), stat.Uid, uid)
+	}
+
+	if gid >= 0 && int(stat.Gid) != gid {
+		return fmt.Errorf("%s: group %d is not %d", info.Name(), stat.Gid, gid)
+	}
+
+	return nil
+}
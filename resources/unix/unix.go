@@ -0,0 +1,121 @@
+// Package unix provides a resource connecting to a Unix domain socket
+package unix
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+const (
+	Scheme = "unix"
+
+	DefaultTimeout = 5 * time.Second
+)
+
+// Unix connects to a socket and optionally checks its permissions and ownership,
+// e.g. unix:///var/run/docker.sock?mode=0660&gid=998
+type Unix struct {
+	path    string
+	timeout time.Duration
+	mode    fs.FileMode
+	uid     int
+	gid     int
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	path := u.Host + u.Path
+
+	if path == "" {
+		return nil, fmt.Errorf("%q: %w", "path", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	timeout, err := query.Duration(q, "timeout", DefaultTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var mode uint64
+
+	if value := q.Get("mode"); value != "" {
+		if mode, err = strconv.ParseUint(value, 8, 32); err != nil {
+			return nil, fmt.Errorf("%q: %s: %w", "mode", err, waitfor.ErrInvalidArgument)
+		}
+	}
+
+	uid, err := query.Int(q, "uid", -1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	gid, err := query.Int(q, "gid", -1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Unix{
+		path:    path,
+		timeout: timeout,
+		mode:    fs.FileMode(mode),
+		uid:     uid,
+		gid:     gid,
+	}, nil
+}
+
+func (u *Unix) Test(ctx context.Context) error {
+	if err := u.checkFile(); err != nil {
+		return err
+	}
+
+	d := net.Dialer{Timeout: u.timeout}
+
+	conn, err := d.DialContext(ctx, Scheme, u.path)
+
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+func (u *Unix) checkFile() error {
+	if u.mode == 0 && u.uid < 0 && u.gid < 0 {
+		return nil
+	}
+
+	info, err := os.Stat(u.path)
+
+	if err != nil {
+		return err
+	}
+
+	// the mode lists permission bits the socket must have
+	if perm := info.Mode().Perm(); perm&u.mode != u.mode {
+		return fmt.Errorf("%s: permissions %#o do not include %#o", u.path, perm, u.mode)
+	}
+
+	return checkOwner(info, u.uid, u.gid)
+}
@@ -0,0 +1,52 @@
+package unix
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnix_Test(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.sock")
+
+	u, _ := url.Parse("unix://" + path)
+	rsc, err := New(u)
+
+	assert.NoError(t, err)
+	assert.Error(t, rsc.Test(context.Background()))
+
+	l, err := net.Listen("unix", path)
+
+	assert.NoError(t, err)
+
+	defer l.Close()
+
+	assert.NoError(t, rsc.Test(context.Background()))
+}
+
+func TestUnix_Test_Permissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.sock")
+	l, err := net.Listen("unix", path)
+
+	assert.NoError(t, err)
+
+	defer l.Close()
+
+	assert.NoError(t, os.Chmod(path, 0600))
+
+	u, _ := url.Parse("unix://" + path + "?mode=0660")
+	rsc, _ := New(u)
+
+	assert.Error(t, rsc.Test(context.Background()))
+
+	u, _ = url.Parse("unix://" + path + "?mode=0600&uid=" + strconv.Itoa(os.Getuid()))
+	rsc, _ = New(u)
+
+	assert.NoError(t, rsc.Test(context.Background()))
+}
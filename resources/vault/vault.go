@@ -0,0 +1,127 @@
+// Package vault provides a resource checking HashiCorp Vault seal status
+package vault
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"github.com/go-waitfor/waitfor/internal/tlsconfig"
+)
+
+const (
+	Scheme = "vault"
+
+	DefaultTimeout = 5 * time.Second
+)
+
+// Vault waits for the node to be initialized, unsealed and active. Standby nodes are
+// accepted when standby is set, e.g. vault://localhost:8200?standby=true&tls=true
+type Vault struct {
+	url     *url.URL
+	standby bool
+	timeout time.Duration
+	client  *http.Client
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	v := &Vault{
+		url: &url.URL{Scheme: "http", Host: u.Host, Path: "/v1/sys/health"},
+	}
+
+	if u.Port() == "" {
+		v.url.Host = net.JoinHostPort(u.Hostname(), "8200")
+	}
+
+	var err error
+
+	if v.standby, err = query.Bool(q, "standby", false); err != nil {
+		return nil, err
+	}
+
+	if v.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	secure, err := query.Bool(q, "tls", false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if secure {
+		v.url.Scheme = "https"
+
+		if transport.TLSClientConfig, err = tlsconfig.FromQuery(q, &tls.Config{}); err != nil {
+			return nil, err
+		}
+	}
+
+	v.client = &http.Client{Transport: transport}
+
+	return v, nil
+}
+
+func (v *Vault) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.url.String(), nil)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := v.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	// the status code encodes the node state, the body is decoded for a descriptive error
+	var health struct {
+		Initialized bool `json:"initialized"`
+		Sealed      bool `json:"sealed"`
+		Standby     bool `json:"standby"`
+	}
+
+	if err := json.NewDecoder(io.LimitReader(res.Body, 64*1024)).Decode(&health); err != nil {
+		return fmt.Errorf("status code %d: %w", res.StatusCode, err)
+	}
+
+	switch {
+	case !health.Initialized:
+		return errors.New("vault is not initialized")
+	case health.Sealed:
+		return errors.New("vault is sealed")
+	case health.Standby && !v.standby:
+		return errors.New("vault is a standby node")
+	}
+
+	return nil
+}
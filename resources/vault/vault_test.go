@@ -0,0 +1,72 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(t *testing.T, code int, initialized, sealed, standby bool) string {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/sys/health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(code)
+		fmt.Fprintf(w, `{"initialized":%t,"sealed":%t,"standby":%t}`, initialized, sealed, standby)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+func TestVault_Test(t *testing.T) {
+	active := serve(t, http.StatusOK, true, false, false)
+	standby := serve(t, http.StatusTooManyRequests, true, false, true)
+	sealed := serve(t, http.StatusServiceUnavailable, true, true, false)
+	uninitialized := serve(t, http.StatusNotImplemented, false, true, false)
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"vault://" + active, true},
+		{"vault://" + standby, false},
+		{"vault://" + standby + "?standby=true", true},
+		{"vault://" + sealed + "?standby=true", false},
+		{"vault://" + uninitialized, false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"vault://localhost?standby=maybe", "vault://localhost?timeout=x", "vault://localhost?tls=true&ca=missing.pem"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
@@ -0,0 +1,260 @@
+// Package websocket provides a resource performing a WebSocket handshake
+package websocket
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+	"github.com/go-waitfor/waitfor/internal/tlsconfig"
+)
+
+const (
+	Scheme       = "ws"
+	SecureScheme = "wss"
+
+	DefaultTimeout = 5 * time.Second
+
+	acceptGUID     = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	maxMessageSize = 64 * 1024
+)
+
+// params are resource options, they are removed from the handshake URL
+var params = append([]string{"subprotocol", "expect", "timeout"}, tlsconfig.Params...)
+
+// WebSocket performs the upgrade handshake and optionally waits for a first message,
+// e.g. ws://localhost:8080/events?subprotocol=graphql-ws&expect=connection_ack
+type WebSocket struct {
+	url         *url.URL
+	subprotocol string
+	expect      string
+	timeout     time.Duration
+	client      *http.Client
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme, SecureScheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	timeout, err := query.Duration(q, "timeout", DefaultTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := tlsconfig.FromQuery(q, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	target := *u
+	target.Scheme = "http"
+
+	if u.Scheme == SecureScheme {
+		target.Scheme = "https"
+	}
+
+	for _, p := range params {
+		q.Del(p)
+	}
+
+	target.RawQuery = q.Encode()
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &WebSocket{
+		url:         &target,
+		subprotocol: u.Query().Get("subprotocol"),
+		expect:      u.Query().Get("expect"),
+		timeout:     timeout,
+		client:      &http.Client{Transport: transport},
+	}, nil
+}
+
+func (w *WebSocket) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, w.url.String(), nil)
+
+	if err != nil {
+		return err
+	}
+
+	key := make([]byte, 16)
+
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+
+	nonce := base64.StdEncoding.EncodeToString(key)
+
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", nonce)
+
+	if w.subprotocol != "" {
+		req.Header.Set("Sec-WebSocket-Protocol", w.subprotocol)
+	}
+
+	res, err := w.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusSwitchingProtocols {
+		return fmt.Errorf("unexpected status: %s", res.Status)
+	}
+
+	if res.Header.Get("Sec-WebSocket-Accept") != acceptKey(nonce) {
+		return errors.New("invalid Sec-WebSocket-Accept header")
+	}
+
+	if w.subprotocol != "" && res.Header.Get("Sec-WebSocket-Protocol") != w.subprotocol {
+		return fmt.Errorf("subprotocol %q is not accepted", w.subprotocol)
+	}
+
+	conn, ok := res.Body.(io.ReadWriteCloser)
+
+	if !ok {
+		return errors.New("connection is not upgraded")
+	}
+
+	// close the connection once the context is done to unblock reading
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	if w.expect != "" {
+		msg, err := readMessage(bufio.NewReader(conn))
+
+		if err != nil {
+			return fmt.Errorf("read message: %w", err)
+		}
+
+		if !strings.Contains(string(msg), w.expect) {
+			return fmt.Errorf("unexpected message: %q", msg)
+		}
+	}
+
+	return writeClose(conn)
+}
+
+func acceptKey(nonce string) string {
+	h := sha1.New()
+	h.Write([]byte(nonce + acceptGUID))
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// readMessage reads a first data frame skipping control frames, fragmented messages are not assembled
+func readMessage(r *bufio.Reader) ([]byte, error) {
+	for {
+		header := make([]byte, 2)
+
+		if _, err := io.ReadFull(r, header); err != nil {
+			return nil, err
+		}
+
+		opcode := header[0] & 0x0f
+		masked := header[1]&0x80 != 0
+		size := uint64(header[1] & 0x7f)
+
+		switch size {
+		case 126:
+			ext := make([]byte, 2)
+
+			if _, err := io.ReadFull(r, ext); err != nil {
+				return nil, err
+			}
+
+			size = uint64(binary.BigEndian.Uint16(ext))
+		case 127:
+			ext := make([]byte, 8)
+
+			if _, err := io.ReadFull(r, ext); err != nil {
+				return nil, err
+			}
+
+			size = binary.BigEndian.Uint64(ext)
+		}
+
+		if size > maxMessageSize {
+			return nil, fmt.Errorf("message of %d bytes is too large", size)
+		}
+
+		mask := make([]byte, 4)
+
+		if masked {
+			if _, err := io.ReadFull(r, mask); err != nil {
+				return nil, err
+			}
+		}
+
+		payload := make([]byte, size)
+
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return nil, err
+		}
+
+		if masked {
+			for i := range payload {
+				payload[i] ^= mask[i%4]
+			}
+		}
+
+		switch opcode {
+		case 0x8:
+			return nil, errors.New("connection is closed by server")
+		case 0x9, 0xa:
+			continue
+		default:
+			return payload, nil
+		}
+	}
+}
+
+// writeClose sends a masked close frame with a normal closure code
+func writeClose(w io.Writer) error {
+	frame := []byte{0x88, 0x82, 0, 0, 0, 0, 0x03, 0xe8}
+
+	if _, err := rand.Read(frame[2:6]); err != nil {
+		return err
+	}
+
+	for i := 6; i < len(frame); i++ {
+		frame[i] ^= frame[2+(i-6)%4]
+	}
+
+	_, err := w.Write(frame)
+
+	return err
+}
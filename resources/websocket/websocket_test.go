@@ -0,0 +1,76 @@
+package websocket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "websocket" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		conn, rw, err := w.(http.Hijacker).Hijack()
+
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		defer conn.Close()
+
+		_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+		_, _ = rw.WriteString("Sec-WebSocket-Accept: " + acceptKey(r.Header.Get("Sec-WebSocket-Key")) + "\r\n")
+
+		if protocol := r.Header.Get("Sec-WebSocket-Protocol"); protocol == "chat" {
+			_, _ = rw.WriteString("Sec-WebSocket-Protocol: chat\r\n")
+		}
+
+		_, _ = rw.WriteString("\r\n")
+		_, _ = rw.Write([]byte{0x89, 0x00})
+		_, _ = rw.Write(append([]byte{0x81, 0x05}, "hello"...))
+		_ = rw.Flush()
+
+		buf := make([]byte, 8)
+		_, _ = rw.Read(buf)
+	}))
+}
+
+func TestWebSocket_Test(t *testing.T) {
+	srv := newServer(t)
+	defer srv.Close()
+
+	base := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{base, true},
+		{base + "?subprotocol=chat&expect=hello", true},
+		{base + "?subprotocol=mqtt", false},
+		{base + "?expect=bye", false},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+// Package zookeeper provides a resource checking ZooKeeper servers
+package zookeeper
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/internal/query"
+)
+
+const (
+	Scheme = "zookeeper"
+
+	DefaultTimeout = 5 * time.Second
+
+	opExists       int32 = 3
+	opCloseSession int32 = -11
+	errNoNode      int32 = -101
+)
+
+// ZooKeeper sends the ruok four-letter word (or asks the admin server when its port is set)
+// and optionally requires a znode to exist, e.g. zookeeper://localhost:2181?znode=/brokers/ids/1
+// or zookeeper://localhost:2181?adminPort=8080
+type ZooKeeper struct {
+	addr      string
+	adminAddr string
+	znode     string
+	timeout   time.Duration
+}
+
+func Use() waitfor.ResourceConfig {
+	return waitfor.ResourceConfig{
+		Scheme:  []string{Scheme},
+		Factory: New,
+	}
+}
+
+func New(u *url.URL) (waitfor.Resource, error) {
+	if u == nil {
+		return nil, fmt.Errorf("%q: %w", "url", waitfor.ErrInvalidArgument)
+	}
+
+	q := u.Query()
+	z := &ZooKeeper{
+		addr:  u.Host,
+		znode: q.Get("znode"),
+	}
+
+	if u.Port() == "" {
+		z.addr = net.JoinHostPort(u.Hostname(), "2181")
+	}
+
+	if z.znode != "" && z.znode[0] != '/' {
+		return nil, fmt.Errorf("%q: must be an absolute path: %w", "znode", waitfor.ErrInvalidArgument)
+	}
+
+	adminPort, err := query.Int(q, "adminPort", 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if adminPort > 0 {
+		z.adminAddr = net.JoinHostPort(u.Hostname(), strconv.Itoa(adminPort))
+	}
+
+	if z.timeout, err = query.Duration(q, "timeout", DefaultTimeout); err != nil {
+		return nil, err
+	}
+
+	return z, nil
+}
+
+func (z *ZooKeeper) Test(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, z.timeout)
+	defer cancel()
+
+	var err error
+
+	if z.adminAddr != "" {
+		err = z.adminRuok(ctx)
+	} else {
+		err = z.ruok(ctx)
+	}
+
+	if err != nil || z.znode == "" {
+		return err
+	}
+
+	return z.exists(ctx)
+}
+
+func (z *ZooKeeper) dial(ctx context.Context) (net.Conn, error) {
+	var d net.Dialer
+
+	conn, err := d.DialContext(ctx, "tcp", z.addr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	return conn, nil
+}
+
+// ruok sends the four-letter word, the server closes the connection after the answer
+func (z *ZooKeeper) ruok(ctx context.Context) error {
+	conn, err := z.dial(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("ruok")); err != nil {
+		return err
+	}
+
+	answer, err := io.ReadAll(io.LimitReader(conn, 1024))
+
+	if err != nil {
+		return err
+	}
+
+	if string(answer) != "imok" {
+		return fmt.Errorf("ruok: unexpected answer %q", answer)
+	}
+
+	return nil
+}
+
+// adminRuok runs the ruok command of the admin server
+func (z *ZooKeeper) adminRuok(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+z.adminAddr+"/commands/ruok", nil)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := http.DefaultClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("ruok: unexpected status code %d", res.StatusCode)
+	}
+
+	var out struct {
+		Error *string `json:"error"`
+	}
+
+	if err := json.NewDecoder(io.LimitReader(res.Body, 64*1024)).Decode(&out); err != nil {
+		return fmt.Errorf("ruok: %w", err)
+	}
+
+	if out.Error != nil {
+		return fmt.Errorf("ruok: %s", *out.Error)
+	}
+
+	return nil
+}
+
+// exists opens a session and checks the znode with the binary protocol
+func (z *ZooKeeper) exists(ctx context.Context) error {
+	conn, err := z.dial(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	// protocol version, last seen zxid, session timeout, session id and empty password
+	connect := appendInt32(nil, 0)
+	connect = appendInt64(connect, 0)
+	connect = appendInt32(connect, int32(z.timeout.Milliseconds()))
+	connect = appendInt64(connect, 0)
+	connect = appendInt32(connect, 16)
+	connect = append(connect, make([]byte, 16)...)
+
+	if _, err := roundTrip(conn, connect); err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+
+	// xid, op code, path and no watch
+	req := appendInt32(nil, 1)
+	req = appendInt32(req, opExists)
+	req = appendInt32(req, int32(len(z.znode)))
+	req = append(append(req, z.znode...), 0)
+
+	res, err := roundTrip(conn, req)
+
+	if err != nil {
+		return fmt.Errorf("exists: %w", err)
+	}
+
+	// the reply header is xid, zxid and error code
+	if len(res) < 16 {
+		return errors.New("exists: malformed reply")
+	}
+
+	_ = writePacket(conn, appendInt32(appendInt32(nil, 2), opCloseSession))
+
+	switch code := int32(binary.BigEndian.Uint32(res[12:])); code {
+	case 0:
+		return nil
+	case errNoNode:
+		return fmt.Errorf("znode %q does not exist", z.znode)
+	default:
+		return fmt.Errorf("exists: error code %d", code)
+	}
+}
+
+func appendInt32(b []byte, v int32) []byte {
+	return binary.BigEndian.AppendUint32(b, uint32(v))
+}
+
+func appendInt64(b []byte, v int64) []byte {
+	return binary.BigEndian.AppendUint64(b, uint64(v))
+}
+
+func writePacket(w io.Writer, payload []byte) error {
+	_, err := w.Write(append(binary.BigEndian.AppendUint32(nil, uint32(len(payload))), payload...))
+
+	return err
+}
+
+// roundTrip sends a request and reads its reply
+func roundTrip(rw io.ReadWriter, payload []byte) ([]byte, error) {
+	if err := writePacket(rw, payload); err != nil {
+		return nil, err
+	}
+
+	return readPacket(rw)
+}
+
+// readPacket reads a length prefixed packet
+func readPacket(r io.Reader) ([]byte, error) {
+	header := make([]byte, 4)
+
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header)
+
+	if size > 1024*1024 {
+		return nil, fmt.Errorf("invalid packet size %d", size)
+	}
+
+	res := make([]byte, size)
+
+	if _, err := io.ReadFull(r, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
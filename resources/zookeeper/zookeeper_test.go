@@ -0,0 +1,118 @@
+package zookeeper
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+// serve starts a fake server answering ruok and knowing the /ready znode
+func serve(t *testing.T, answer string) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	assert.NoError(t, err)
+
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			c, err := l.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer c.Close()
+
+				header := make([]byte, 4)
+
+				if _, err := io.ReadFull(c, header); err != nil {
+					return
+				}
+
+				if string(header) == "ruok" {
+					fmt.Fprint(c, answer)
+					return
+				}
+
+				// skip the connect request and answer with an empty session
+				_, _ = io.ReadFull(c, make([]byte, binary.BigEndian.Uint32(header)))
+				_ = writePacket(c, make([]byte, 36))
+
+				req, err := readPacket(c)
+
+				if err != nil || len(req) < 12 {
+					return
+				}
+
+				path := string(req[12 : 12+binary.BigEndian.Uint32(req[8:])])
+				code := int32(0)
+
+				if path != "/ready" {
+					code = errNoNode
+				}
+
+				_ = writePacket(c, appendInt32(appendInt64(appendInt32(nil, 1), 1), code))
+			}()
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func TestZooKeeper_Test(t *testing.T) {
+	ok := serve(t, "imok")
+	notOK := serve(t, "")
+
+	admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"command":"ruok","error":null}`)
+	}))
+	defer admin.Close()
+
+	adminURL, _ := url.Parse(admin.URL)
+
+	cases := []struct {
+		location string
+		ok       bool
+	}{
+		{"zookeeper://" + ok, true},
+		{"zookeeper://" + notOK, false},
+		{"zookeeper://" + ok + "?znode=/ready", true},
+		{"zookeeper://" + ok + "?znode=/missing", false},
+		{"zookeeper://" + notOK + "?adminPort=" + adminURL.Port(), true},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.location)
+		rsc, err := New(u)
+
+		assert.NoError(t, err, c.location)
+
+		err = rsc.Test(context.Background())
+
+		if c.ok {
+			assert.NoError(t, err, c.location)
+		} else {
+			assert.Error(t, err, c.location)
+		}
+	}
+}
+
+func TestNew_InvalidArgument(t *testing.T) {
+	for _, location := range []string{"zookeeper://localhost?znode=ready", "zookeeper://localhost?adminPort=x", "zookeeper://localhost?timeout=x"} {
+		u, _ := url.Parse(location)
+		_, err := New(u)
+
+		assert.ErrorIs(t, err, waitfor.ErrInvalidArgument, location)
+	}
+}
@@ -0,0 +1,170 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/go-waitfor/waitfor/server/serverpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+//go:generate protoc -I serverpb --go_out=serverpb --go_opt=paths=source_relative --go-grpc_out=serverpb --go-grpc_opt=paths=source_relative server.proto
+
+// watchBuffer is the number of states buffered for a slow Watch client
+const watchBuffer = 64
+
+type (
+	// grpcService implements the Readiness gRPC service on top of the server
+	grpcService struct {
+		serverpb.UnimplementedReadinessServer
+
+		s *Server
+	}
+
+	// grpcAdminService implements the ReadinessAdmin gRPC service on top of the server
+	grpcAdminService struct {
+		serverpb.UnimplementedReadinessAdminServer
+
+		s *Server
+	}
+)
+
+// RegisterGRPC registers the Readiness service, clients are created by serverpb.NewReadinessClient.
+// The ReadinessAdmin service adding and removing resources is only registered if adding resources
+// at runtime is enabled, see WithRuntimeResources.
+func (s *Server) RegisterGRPC(registrar grpc.ServiceRegistrar) {
+	serverpb.RegisterReadinessServer(registrar, &grpcService{s: s})
+
+	if s.runtimeEnabled() {
+		serverpb.RegisterReadinessAdminServer(registrar, &grpcAdminService{s: s})
+	}
+}
+
+func (g *grpcService) ListResources(_ context.Context, _ *serverpb.ListResourcesRequest) (*serverpb.ListResourcesResponse, error) {
+	return &serverpb.ListResourcesResponse{
+		Resources: toProtos(g.s.List()),
+		Ready:     g.s.Ready(),
+	}, nil
+}
+
+func (g *grpcAdminService) AddResource(_ context.Context, req *serverpb.AddResourceRequest) (*serverpb.Status, error) {
+	status, err := g.s.addRuntime(req.GetResource())
+
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return toProto(status), nil
+}
+
+func (g *grpcAdminService) RemoveResource(_ context.Context, req *serverpb.RemoveResourceRequest) (*serverpb.RemoveResourceResponse, error) {
+	if err := g.s.removeRuntime(req.GetId()); err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &serverpb.RemoveResourceResponse{}, nil
+}
+
+func (g *grpcService) Check(ctx context.Context, req *serverpb.CheckRequest) (*serverpb.CheckResponse, error) {
+	if len(req.GetIds()) == 0 {
+		return &serverpb.CheckResponse{Resources: toProtos(g.s.CheckAll(ctx))}, nil
+	}
+
+	res := new(serverpb.CheckResponse)
+
+	for _, id := range req.GetIds() {
+		status, err := g.s.Check(ctx, id)
+
+		if err != nil {
+			return nil, grpcError(err)
+		}
+
+		res.Resources = append(res.Resources, toProto(status))
+	}
+
+	return res, nil
+}
+
+func (g *grpcService) Watch(req *serverpb.WatchRequest, stream grpc.ServerStreamingServer[serverpb.Status]) error {
+	// the subscription starts before the current states are sent, so no check is missed
+	updates, cancel := g.s.Subscribe(watchBuffer)
+	defer cancel()
+
+	ids := req.GetIds()
+	watched := func(id string) bool {
+		return len(ids) == 0 || slices.Contains(ids, id)
+	}
+
+	for _, status := range g.s.List() {
+		if !watched(status.ID) {
+			continue
+		}
+
+		if err := stream.Send(toProto(status)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case status := <-updates:
+			if !watched(status.ID) {
+				continue
+			}
+
+			if err := stream.Send(toProto(status)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// grpcError maps server errors to gRPC status codes, other errors come from resource resolution
+func grpcError(err error) error {
+	code := codes.InvalidArgument
+
+	switch {
+	case errors.Is(err, ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, ErrExists):
+		code = codes.AlreadyExists
+	case errors.Is(err, ErrForbidden):
+		code = codes.PermissionDenied
+	}
+
+	return grpcstatus.Error(code, err.Error())
+}
+
+func toProto(status Status) *serverpb.Status {
+	pb := &serverpb.Status{
+		Id:        status.ID,
+		Resource:  status.Resource,
+		Ready:     status.Ready,
+		Checks:    int64(status.Checks),
+		LatencyMs: status.Latency,
+		Error:     status.Error,
+	}
+
+	if !status.CheckedAt.IsZero() {
+		pb.CheckedAt = timestamppb.New(status.CheckedAt)
+		pb.Since = timestamppb.New(status.Since)
+	}
+
+	return pb
+}
+
+func toProtos(list []Status) []*serverpb.Status {
+	pbs := make([]*serverpb.Status, 0, len(list))
+
+	for _, status := range list {
+		pbs = append(pbs, toProto(status))
+	}
+
+	return pbs
+}
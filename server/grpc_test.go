@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/go-waitfor/waitfor/server/serverpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newClient(t *testing.T, s *Server) (serverpb.ReadinessClient, serverpb.ReadinessAdminClient) {
+	l := bufconn.Listen(1024 * 1024)
+	g := grpc.NewServer()
+	s.RegisterGRPC(g)
+
+	go func() {
+		_ = g.Serve(l)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return l.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		g.Stop()
+	})
+
+	return serverpb.NewReadinessClient(conn), serverpb.NewReadinessAdminClient(conn)
+}
+
+func TestServer_GRPC(t *testing.T) {
+	s, rsc := newServer(WithRuntimeResources("toggle", "unknown"))
+	client, admin := newClient(t, s)
+	ctx := context.Background()
+
+	added, err := admin.AddResource(ctx, &serverpb.AddResourceRequest{Resource: "toggle://user:secret@db"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ID("toggle://user:secret@db"), added.GetId())
+	assert.Equal(t, "toggle://user:xxxxx@db", added.GetResource())
+	assert.Nil(t, added.GetCheckedAt())
+
+	_, err = admin.AddResource(ctx, &serverpb.AddResourceRequest{Resource: "toggle://user:secret@db"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = admin.AddResource(ctx, &serverpb.AddResourceRequest{Resource: "unknown://db"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = admin.AddResource(ctx, &serverpb.AddResourceRequest{Resource: "exec:///bin/touch?arg=/tmp/x"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	list, err := client.ListResources(ctx, &serverpb.ListResourcesRequest{})
+
+	assert.NoError(t, err)
+	assert.Len(t, list.GetResources(), 1)
+	assert.False(t, list.GetReady())
+
+	checked, err := client.Check(ctx, &serverpb.CheckRequest{Ids: []string{added.GetId()}})
+
+	assert.NoError(t, err)
+	assert.True(t, checked.GetResources()[0].GetReady())
+	assert.NotNil(t, checked.GetResources()[0].GetCheckedAt())
+
+	_, err = client.Check(ctx, &serverpb.CheckRequest{Ids: []string{"missing"}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	rsc.down.Store(true)
+
+	checked, err = client.Check(ctx, &serverpb.CheckRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "connection refused", checked.GetResources()[0].GetError())
+
+	_, err = admin.RemoveResource(ctx, &serverpb.RemoveResourceRequest{Id: added.GetId()})
+	assert.NoError(t, err)
+
+	_, err = admin.RemoveResource(ctx, &serverpb.RemoveResourceRequest{Id: added.GetId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_GRPC_ReadOnly(t *testing.T) {
+	s, _ := newServer()
+	client, admin := newClient(t, s)
+	ctx := context.Background()
+
+	_, err := admin.AddResource(ctx, &serverpb.AddResourceRequest{Resource: "toggle://db"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = admin.RemoveResource(ctx, &serverpb.RemoveResourceRequest{Id: ID("toggle://db")})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	list, err := client.ListResources(ctx, &serverpb.ListResourcesRequest{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, list.GetResources())
+}
+
+func TestServer_GRPC_Watch(t *testing.T) {
+	s, _ := newServer()
+	client, _ := newClient(t, s)
+
+	db, err := s.Add("toggle://db")
+	assert.NoError(t, err)
+
+	_, err = s.Add("toggle://cache")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &serverpb.WatchRequest{Ids: []string{db.ID}})
+	assert.NoError(t, err)
+
+	current, err := stream.Recv()
+
+	assert.NoError(t, err)
+	assert.Equal(t, db.ID, current.GetId())
+	assert.Zero(t, current.GetChecks())
+
+	s.CheckAll(context.Background())
+
+	update, err := stream.Recv()
+
+	assert.NoError(t, err)
+	assert.Equal(t, db.ID, update.GetId())
+	assert.True(t, update.GetReady())
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+type (
+	// addRequest is a body of a request adding a resource
+	addRequest struct {
+		Resource string `json:"resource"`
+	}
+
+	errorResponse struct {
+		Error string `json:"error"`
+	}
+)
+
+// Handler returns the REST API of the server:
+//
+//	GET    /resources             states of all resources
+//	POST   /resources             watch a resource, the body is {"resource": "postgres://db:5432"}, see WithRuntimeResources
+//	POST   /resources/check       check all resources immediately
+//	GET    /resources/{id}        state of a resource
+//	DELETE /resources/{id}        stop watching a resource, see WithRuntimeResources
+//	POST   /resources/{id}/check  check a resource immediately
+//	GET    /ready                 200 if all resources are ready, 503 otherwise
+//
+// POST /resources and DELETE /resources/{id} are only served if adding resources at runtime is enabled.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /resources", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, s.List())
+	})
+
+	mux.HandleFunc("POST /resources/check", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.CheckAll(r.Context()))
+	})
+
+	mux.HandleFunc("GET /resources/{id}", func(w http.ResponseWriter, r *http.Request) {
+		status, err := s.Status(r.PathValue("id"))
+
+		if err != nil {
+			writeError(w, statusCode(err), err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, status)
+	})
+
+	mux.HandleFunc("POST /resources/{id}/check", func(w http.ResponseWriter, r *http.Request) {
+		status, err := s.Check(r.Context(), r.PathValue("id"))
+
+		if err != nil {
+			writeError(w, statusCode(err), err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, status)
+	})
+
+	if s.runtimeEnabled() {
+		mux.HandleFunc("POST /resources", func(w http.ResponseWriter, r *http.Request) {
+			var req addRequest
+
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Resource == "" {
+				writeError(w, http.StatusBadRequest, errors.New("body must be {\"resource\": \"<url>\"}"))
+				return
+			}
+
+			status, err := s.addRuntime(req.Resource)
+
+			if err != nil {
+				writeError(w, statusCode(err), err)
+				return
+			}
+
+			writeJSON(w, http.StatusCreated, status)
+		})
+
+		mux.HandleFunc("DELETE /resources/{id}", func(w http.ResponseWriter, r *http.Request) {
+			if err := s.removeRuntime(r.PathValue("id")); err != nil {
+				writeError(w, statusCode(err), err)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+
+	mux.HandleFunc("GET /ready", func(w http.ResponseWriter, _ *http.Request) {
+		code := http.StatusOK
+
+		if !s.Ready() {
+			code = http.StatusServiceUnavailable
+		}
+
+		writeJSON(w, code, s.List())
+	})
+
+	return mux
+}
+
+// statusCode maps server errors to HTTP status codes, other errors come from resource resolution
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrExists):
+		return http.StatusConflict
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, errorResponse{Error: err.Error()})
+}
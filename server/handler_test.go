@@ -0,0 +1,92 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_Handler(t *testing.T) {
+	s, rsc := newServer(WithRuntimeResources("toggle", "unknown"))
+	h := s.Handler()
+	id := ID("toggle://db")
+
+	cases := []struct {
+		method string
+		path   string
+		body   string
+		code   int
+		out    string
+	}{
+		{http.MethodGet, "/ready", "", http.StatusOK, "[]"},
+		{http.MethodPost, "/resources", `{"resource": "toggle://db"}`, http.StatusCreated, `"id":"` + id + `"`},
+		{http.MethodPost, "/resources", `{"resource": "toggle://db"}`, http.StatusConflict, "already watched"},
+		{http.MethodPost, "/resources", `{"resource": "unknown://db"}`, http.StatusBadRequest, "scheme is not found"},
+		{http.MethodPost, "/resources", `[]`, http.StatusBadRequest, "body must be"},
+		{http.MethodPost, "/resources", `{"resource": "exec:///bin/touch?arg=/tmp/x"}`, http.StatusForbidden, "not allowed"},
+		{http.MethodGet, "/ready", "", http.StatusServiceUnavailable, `"ready":false`},
+		{http.MethodPost, "/resources/" + id + "/check", "", http.StatusOK, `"ready":true`},
+		{http.MethodGet, "/resources/" + id, "", http.StatusOK, `"checks":1`},
+		{http.MethodGet, "/ready", "", http.StatusOK, `"ready":true`},
+		{http.MethodPost, "/resources/check", "", http.StatusOK, `"checks":2`},
+		{http.MethodDelete, "/resources/" + id, "", http.StatusNoContent, ""},
+		{http.MethodDelete, "/resources/" + id, "", http.StatusNotFound, "not watched"},
+		{http.MethodGet, "/resources/" + id, "", http.StatusNotFound, "not watched"},
+		{http.MethodPost, "/resources/" + id + "/check", "", http.StatusNotFound, "not watched"},
+		{http.MethodGet, "/resources", "", http.StatusOK, "[]"},
+	}
+
+	for _, c := range cases {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(c.method, c.path, strings.NewReader(c.body)))
+
+		assert.Equal(t, c.code, rec.Code, c.method, c.path)
+		assert.Contains(t, rec.Body.String(), c.out, c.method, c.path)
+	}
+
+	rsc.down.Store(true)
+
+	_, err := s.Add("toggle://db")
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/resources/check", nil))
+
+	var list []Status
+
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&list))
+	assert.Len(t, list, 1)
+	assert.Equal(t, "connection refused", list[0].Error)
+}
+
+func TestServer_Handler_ReadOnly(t *testing.T) {
+	s, _ := newServer()
+	h := s.Handler()
+
+	_, err := s.Add("toggle://db")
+	assert.NoError(t, err)
+
+	cases := []struct {
+		method string
+		path   string
+		body   string
+		code   int
+	}{
+		{http.MethodPost, "/resources", `{"resource": "toggle://cache"}`, http.StatusMethodNotAllowed},
+		{http.MethodDelete, "/resources/" + ID("toggle://db"), "", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/resources", "", http.StatusOK},
+	}
+
+	for _, c := range cases {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(c.method, c.path, strings.NewReader(c.body)))
+
+		assert.Equal(t, c.code, rec.Code, c.method, c.path)
+	}
+
+	assert.Len(t, s.List(), 1)
+}
@@ -0,0 +1,469 @@
+// Package server keeps resources under continuous watch and exposes their state over a REST API,
+// so a single readiness service per host can replace many wrapper processes
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+)
+
+const (
+	// DefaultInterval is a default interval between checks of every resource
+	DefaultInterval = 10 * time.Second
+	// DefaultCheckTimeout is a default timeout of a single check
+	DefaultCheckTimeout = 5 * time.Second
+)
+
+var (
+	// ErrNotFound means the resource is not watched
+	ErrNotFound = errors.New("resource is not watched")
+	// ErrExists means the resource is already watched
+	ErrExists = errors.New("resource is already watched")
+	// ErrForbidden means the resource cannot be added or removed over the API
+	ErrForbidden = errors.New("resource is not allowed at runtime")
+)
+
+// NetworkSchemes are schemes of resources which may be added over the API by default,
+// they only connect to a remote service. Resources running commands or reading local files,
+// sockets and devices, e.g. exec, file, sql, pid, unix, dir, disk or serial, are never allowed.
+var NetworkSchemes = []string{
+	"tcp", "tcp4", "tcp6", "udp", "udp4", "udp6", "tls", "dns+srv", "ntp",
+	"http", "https", "grpc", "grpcs", "ws", "wss",
+	"mysql", "mariadb", "redis", "rediss", "redis-cluster", "redis-sentinel", "mongodb",
+	"cassandra", "clickhouse", "elasticsearch", "opensearch",
+	"amqp", "amqps", "kafka", "nats", "tls-nats", "zookeeper", "etcd",
+}
+
+type (
+	// Status is the last known state of a watched resource
+	Status struct {
+		// ID identifies the resource in the API without exposing its credentials
+		ID       string `json:"id"`
+		Resource string `json:"resource"`
+		Ready    bool   `json:"ready"`
+		// Checks is the number of checks made, the resource is not ready before the first one
+		Checks    int       `json:"checks"`
+		CheckedAt time.Time `json:"checked_at,omitzero"`
+		// Since is the time of the last change of readiness
+		Since   time.Time `json:"since,omitzero"`
+		Latency float64   `json:"latency_ms"`
+		Error   string    `json:"error,omitempty"`
+	}
+
+	options struct {
+		interval     time.Duration
+		checkTimeout time.Duration
+		// runtime are schemes of resources which may be added over the API, nil disables adding and removing
+		runtime map[string]bool
+	}
+
+	Option func(opts *options)
+
+	// Server checks watched resources periodically until Run returns
+	Server struct {
+		registry *waitfor.Registry
+		opts     options
+		mu       sync.RWMutex
+		watches  map[string]*watch
+		wake     chan struct{}
+		// subscribers receive states after every check
+		subscribers map[chan Status]struct{}
+		// configured are ids of resources managed by Sync
+		configured map[string]bool
+	}
+
+	watch struct {
+		resource waitfor.Resource
+		// check serializes checks of the resource
+		check  sync.Mutex
+		status Status
+	}
+)
+
+// Set an interval between checks of every resource
+func WithInterval(interval time.Duration) Option {
+	return func(opts *options) {
+		opts.interval = interval
+	}
+}
+
+// Set a timeout of a single check
+func WithCheckTimeout(timeout time.Duration) Option {
+	return func(opts *options) {
+		opts.checkTimeout = timeout
+	}
+}
+
+// Allow adding and removing resources over the REST and gRPC APIs, it is disabled by default
+// since the API has no authentication. Only resources of given schemes may be added, NetworkSchemes by default.
+func WithRuntimeResources(schemes ...string) Option {
+	return func(opts *options) {
+		if len(schemes) == 0 {
+			schemes = NetworkSchemes
+		}
+
+		opts.runtime = make(map[string]bool, len(schemes))
+
+		for _, scheme := range schemes {
+			opts.runtime[strings.ToLower(scheme)] = true
+		}
+	}
+}
+
+// New creates a server resolving resources with a given runner
+func New(runner *waitfor.Runner, setters ...Option) *Server {
+	opts := options{
+		interval:     DefaultInterval,
+		checkTimeout: DefaultCheckTimeout,
+	}
+
+	for _, setter := range setters {
+		setter(&opts)
+	}
+
+	return &Server{
+		registry: runner.Resources(),
+		opts:     opts,
+		watches:  make(map[string]*watch),
+		wake:     make(chan struct{}, 1),
+
+		subscribers: make(map[chan Status]struct{}),
+		configured:  make(map[string]bool),
+	}
+}
+
+// Reconfigure changes options of a running server, a new interval applies after the current round of checks
+func (s *Server) Reconfigure(setters ...Option) {
+	s.mu.Lock()
+
+	for _, setter := range setters {
+		setter(&s.opts)
+	}
+
+	s.mu.Unlock()
+	s.notify()
+}
+
+// ID returns the identifier of a resource location used by the API
+func ID(location string) string {
+	sum := sha256.Sum256([]byte(location))
+	return hex.EncodeToString(sum[:6])
+}
+
+// Add starts watching a resource, it is checked on the next round of Run
+func (s *Server) Add(location string) (Status, error) {
+	rsc, err := s.registry.Resolve(location)
+
+	if err != nil {
+		return Status{}, err
+	}
+
+	w := &watch{
+		resource: rsc,
+		status:   Status{ID: ID(location), Resource: waitfor.Redact(location)},
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.watches[w.status.ID]; ok {
+		return Status{}, fmt.Errorf("%s: %w", w.status.Resource, ErrExists)
+	}
+
+	s.watches[w.status.ID] = w
+	s.notify()
+
+	return w.status, nil
+}
+
+// addRuntime starts watching a resource added over the API
+func (s *Server) addRuntime(location string) (Status, error) {
+	if err := s.allowRuntime(location); err != nil {
+		return Status{}, err
+	}
+
+	return s.Add(location)
+}
+
+// removeRuntime stops watching a resource removed over the API
+func (s *Server) removeRuntime(id string) error {
+	if !s.runtimeEnabled() {
+		return fmt.Errorf("%s: %w", id, ErrForbidden)
+	}
+
+	return s.Remove(id)
+}
+
+// allowRuntime checks whether a resource may be added over the API
+func (s *Server) allowRuntime(location string) error {
+	u, err := url.Parse(location)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", waitfor.Redact(location), waitfor.ErrInvalidArgument)
+	}
+
+	// the HTTP check dials a unix socket given by the "socket" option
+	if !s.options().runtime[strings.ToLower(u.Scheme)] || u.Query().Has("socket") {
+		return fmt.Errorf("%s: %w", waitfor.Redact(location), ErrForbidden)
+	}
+
+	return nil
+}
+
+func (s *Server) runtimeEnabled() bool {
+	return s.options().runtime != nil
+}
+
+// Sync makes configured resources match a given list, e.g. on a configuration reload.
+// New resources are added and the ones removed from the list stop being watched,
+// resources added only by Add are kept. Resources which cannot be resolved are skipped and reported.
+func (s *Server) Sync(locations []string) error {
+	var errs []error
+
+	ids := make(map[string]bool, len(locations))
+
+	for _, location := range locations {
+		status, err := s.Add(location)
+
+		if errors.Is(err, ErrExists) {
+			status.ID = ID(location)
+		} else if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		ids[status.ID] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id := range s.configured {
+		if !ids[id] {
+			delete(s.watches, id)
+		}
+	}
+
+	s.configured = ids
+
+	return errors.Join(errs...)
+}
+
+// Remove stops watching a resource with a given id
+func (s *Server) Remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.watches[id]; !ok {
+		return fmt.Errorf("%s: %w", id, ErrNotFound)
+	}
+
+	delete(s.watches, id)
+
+	return nil
+}
+
+// Status returns the state of a resource with a given id
+func (s *Server) Status(id string) (Status, error) {
+	w, err := s.lookup(id)
+
+	if err != nil {
+		return Status{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return w.status, nil
+}
+
+// List returns states of all watched resources ordered by location
+func (s *Server) List() []Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]Status, 0, len(s.watches))
+
+	for _, w := range s.watches {
+		list = append(list, w.status)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Resource < list[j].Resource
+	})
+
+	return list
+}
+
+// Ready reports whether all watched resources are ready
+func (s *Server) Ready() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, w := range s.watches {
+		if !w.status.Ready {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Check checks a resource with a given id immediately and returns its new state
+func (s *Server) Check(ctx context.Context, id string) (Status, error) {
+	w, err := s.lookup(id)
+
+	if err != nil {
+		return Status{}, err
+	}
+
+	return s.check(ctx, w), nil
+}
+
+// CheckAll checks all resources immediately and returns their new states
+func (s *Server) CheckAll(ctx context.Context) []Status {
+	s.mu.RLock()
+
+	watches := make([]*watch, 0, len(s.watches))
+
+	for _, w := range s.watches {
+		watches = append(watches, w)
+	}
+
+	s.mu.RUnlock()
+
+	var wg sync.WaitGroup
+
+	for _, w := range watches {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			s.check(ctx, w)
+		}()
+	}
+
+	wg.Wait()
+
+	return s.List()
+}
+
+// Subscribe returns a channel receiving the state of a resource after every check and a function cancelling the subscription.
+// States are dropped while the channel buffer of a given size is full.
+func (s *Server) Subscribe(size int) (<-chan Status, func()) {
+	ch := make(chan Status, size)
+
+	s.mu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+
+	return ch, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			delete(s.subscribers, ch)
+			close(ch)
+		})
+	}
+}
+
+// Run checks watched resources every interval until the context is done
+func (s *Server) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.options().interval)
+	defer ticker.Stop()
+
+	for {
+		s.CheckAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-s.wake:
+			ticker.Reset(s.options().interval)
+		}
+	}
+}
+
+// notify wakes Run up to check new resources and apply new options
+func (s *Server) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Server) options() options {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.opts
+}
+
+func (s *Server) lookup(id string) (*watch, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	w, ok := s.watches[id]
+
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", id, ErrNotFound)
+	}
+
+	return w, nil
+}
+
+// check tests a resource once and updates its state
+func (s *Server) check(ctx context.Context, w *watch) Status {
+	w.check.Lock()
+	defer w.check.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, s.options().checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := w.resource.Test(ctx)
+	latency := time.Since(start)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := &w.status
+	ready := err == nil
+
+	if status.Checks == 0 || status.Ready != ready {
+		status.Since = start
+	}
+
+	status.Ready = ready
+	status.Checks++
+	status.CheckedAt = start
+	status.Latency = float64(latency.Microseconds()) / 1000
+	status.Error = ""
+
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- *status:
+		default:
+		}
+	}
+
+	return *status
+}
@@ -0,0 +1,203 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/stretchr/testify/assert"
+)
+
+type toggleResource struct {
+	down atomic.Bool
+}
+
+func (r *toggleResource) Test(_ context.Context) error {
+	if r.down.Load() {
+		return errors.New("connection refused")
+	}
+
+	return nil
+}
+
+func newServer(setters ...Option) (*Server, *toggleResource) {
+	rsc := new(toggleResource)
+
+	return New(waitfor.New(waitfor.ResourceConfig{
+		Scheme: []string{"toggle"},
+		Factory: func(_ *url.URL) (waitfor.Resource, error) {
+			return rsc, nil
+		},
+	}), setters...), rsc
+}
+
+func TestServer(t *testing.T) {
+	s, rsc := newServer()
+	ctx := context.Background()
+
+	status, err := s.Add("toggle://user:secret@db")
+
+	assert.NoError(t, err)
+	assert.Equal(t, Status{ID: ID("toggle://user:secret@db"), Resource: "toggle://user:xxxxx@db"}, status)
+	assert.False(t, s.Ready())
+
+	_, err = s.Add("toggle://user:secret@db")
+	assert.ErrorIs(t, err, ErrExists)
+
+	_, err = s.Add("unknown://db")
+	assert.Error(t, err)
+
+	status, err = s.Check(ctx, status.ID)
+
+	assert.NoError(t, err)
+	assert.True(t, status.Ready)
+	assert.Equal(t, 1, status.Checks)
+	assert.True(t, s.Ready())
+
+	rsc.down.Store(true)
+	since := status.Since
+
+	list := s.CheckAll(ctx)
+
+	assert.Len(t, list, 1)
+	assert.False(t, list[0].Ready)
+	assert.Equal(t, 2, list[0].Checks)
+	assert.Equal(t, "connection refused", list[0].Error)
+	assert.True(t, list[0].Since.After(since))
+	assert.False(t, s.Ready())
+
+	assert.NoError(t, s.Remove(status.ID))
+	assert.ErrorIs(t, s.Remove(status.ID), ErrNotFound)
+
+	_, err = s.Status(status.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = s.Check(ctx, status.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.True(t, s.Ready())
+}
+
+func TestServer_Run(t *testing.T) {
+	s, _ := newServer(WithInterval(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	status, err := s.Add("toggle://db")
+
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		status, _ = s.Status(status.ID)
+		return status.Ready
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestServer_Subscribe(t *testing.T) {
+	s, _ := newServer()
+	updates, cancel := s.Subscribe(1)
+
+	status, err := s.Add("toggle://db")
+	assert.NoError(t, err)
+
+	_, err = s.Check(context.Background(), status.ID)
+	assert.NoError(t, err)
+
+	// the buffer is full, the second state is dropped
+	_, err = s.Check(context.Background(), status.ID)
+	assert.NoError(t, err)
+
+	update := <-updates
+
+	assert.Equal(t, status.ID, update.ID)
+	assert.Equal(t, 1, update.Checks)
+
+	cancel()
+	cancel()
+
+	_, ok := <-updates
+
+	assert.False(t, ok)
+}
+
+func TestServer_Sync(t *testing.T) {
+	s, _ := newServer()
+
+	_, err := s.Add("toggle://api")
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.Sync([]string{"toggle://db", "toggle://cache"}))
+	assert.ErrorContains(t, s.Sync([]string{"toggle://db", "toggle://queue", "unknown://db"}), "unknown")
+
+	var resources []string
+
+	for _, status := range s.List() {
+		resources = append(resources, status.Resource)
+	}
+
+	assert.Equal(t, []string{"toggle://api", "toggle://db", "toggle://queue"}, resources)
+}
+
+func TestServer_AllowRuntime(t *testing.T) {
+	cases := []struct {
+		setters  []Option
+		resource string
+		allowed  bool
+	}{
+		{nil, "tcp://db:5432", false},
+		{[]Option{WithRuntimeResources()}, "tcp://db:5432", true},
+		{[]Option{WithRuntimeResources()}, "HTTPS://api/health", true},
+		{[]Option{WithRuntimeResources()}, "http://localhost/containers/json?socket=/var/run/docker.sock", false},
+		{[]Option{WithRuntimeResources()}, "exec:///bin/touch?arg=/tmp/x", false},
+		{[]Option{WithRuntimeResources()}, "file:///etc/passwd", false},
+		{[]Option{WithRuntimeResources()}, "sql://postgres?dsn=x", false},
+		{[]Option{WithRuntimeResources()}, "unix:///var/run/docker.sock", false},
+		{[]Option{WithRuntimeResources()}, "toggle://db", false},
+		{[]Option{WithRuntimeResources("toggle")}, "toggle://db", true},
+		{[]Option{WithRuntimeResources("toggle")}, "tcp://db:5432", false},
+	}
+
+	for _, c := range cases {
+		s, _ := newServer(c.setters...)
+		err := s.allowRuntime(c.resource)
+
+		if c.allowed {
+			assert.NoError(t, err, c.resource)
+		} else {
+			assert.ErrorIs(t, err, ErrForbidden, c.resource)
+		}
+	}
+}
+
+func TestServer_Reconfigure(t *testing.T) {
+	s, _ := newServer(WithInterval(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	status, err := s.Add("toggle://db")
+	assert.NoError(t, err)
+
+	go func() {
+		_ = s.Run(ctx)
+	}()
+
+	s.Reconfigure(WithInterval(10 * time.Millisecond))
+
+	assert.Eventually(t, func() bool {
+		status, _ = s.Status(status.ID)
+		return status.Checks > 3
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,341 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: server.proto
+
+package serverpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Readiness_ListResources_FullMethodName = "/waitfor.server.v1.Readiness/ListResources"
+	Readiness_Check_FullMethodName         = "/waitfor.server.v1.Readiness/Check"
+	Readiness_Watch_FullMethodName         = "/waitfor.server.v1.Readiness/Watch"
+)
+
+// ReadinessClient is the client API for Readiness service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReadinessClient interface {
+	ListResources(ctx context.Context, in *ListResourcesRequest, opts ...grpc.CallOption) (*ListResourcesResponse, error)
+	Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Status], error)
+}
+
+type readinessClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReadinessClient(cc grpc.ClientConnInterface) ReadinessClient {
+	return &readinessClient{cc}
+}
+
+func (c *readinessClient) ListResources(ctx context.Context, in *ListResourcesRequest, opts ...grpc.CallOption) (*ListResourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResourcesResponse)
+	err := c.cc.Invoke(ctx, Readiness_ListResources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readinessClient) Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckResponse)
+	err := c.cc.Invoke(ctx, Readiness_Check_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readinessClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Status], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Readiness_ServiceDesc.Streams[0], Readiness_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, Status]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Readiness_WatchClient = grpc.ServerStreamingClient[Status]
+
+// ReadinessServer is the server API for Readiness service.
+// All implementations must embed UnimplementedReadinessServer
+// for forward compatibility.
+type ReadinessServer interface {
+	ListResources(context.Context, *ListResourcesRequest) (*ListResourcesResponse, error)
+	Check(context.Context, *CheckRequest) (*CheckResponse, error)
+	Watch(*WatchRequest, grpc.ServerStreamingServer[Status]) error
+	mustEmbedUnimplementedReadinessServer()
+}
+
+// UnimplementedReadinessServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReadinessServer struct{}
+
+func (UnimplementedReadinessServer) ListResources(context.Context, *ListResourcesRequest) (*ListResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListResources not implemented")
+}
+func (UnimplementedReadinessServer) Check(context.Context, *CheckRequest) (*CheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
+}
+func (UnimplementedReadinessServer) Watch(*WatchRequest, grpc.ServerStreamingServer[Status]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedReadinessServer) mustEmbedUnimplementedReadinessServer() {}
+func (UnimplementedReadinessServer) testEmbeddedByValue()                   {}
+
+// UnsafeReadinessServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReadinessServer will
+// result in compilation errors.
+type UnsafeReadinessServer interface {
+	mustEmbedUnimplementedReadinessServer()
+}
+
+func RegisterReadinessServer(s grpc.ServiceRegistrar, srv ReadinessServer) {
+	// If the following call pancis, it indicates UnimplementedReadinessServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Readiness_ServiceDesc, srv)
+}
+
+func _Readiness_ListResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReadinessServer).ListResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Readiness_ListResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReadinessServer).ListResources(ctx, req.(*ListResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Readiness_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReadinessServer).Check(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Readiness_Check_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReadinessServer).Check(ctx, req.(*CheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Readiness_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReadinessServer).Watch(m, &grpc.GenericServerStream[WatchRequest, Status]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Readiness_WatchServer = grpc.ServerStreamingServer[Status]
+
+// Readiness_ServiceDesc is the grpc.ServiceDesc for Readiness service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Readiness_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "waitfor.server.v1.Readiness",
+	HandlerType: (*ReadinessServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListResources",
+			Handler:    _Readiness_ListResources_Handler,
+		},
+		{
+			MethodName: "Check",
+			Handler:    _Readiness_Check_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Readiness_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "server.proto",
+}
+
+const (
+	ReadinessAdmin_AddResource_FullMethodName    = "/waitfor.server.v1.ReadinessAdmin/AddResource"
+	ReadinessAdmin_RemoveResource_FullMethodName = "/waitfor.server.v1.ReadinessAdmin/RemoveResource"
+)
+
+// ReadinessAdminClient is the client API for ReadinessAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReadinessAdminClient interface {
+	AddResource(ctx context.Context, in *AddResourceRequest, opts ...grpc.CallOption) (*Status, error)
+	RemoveResource(ctx context.Context, in *RemoveResourceRequest, opts ...grpc.CallOption) (*RemoveResourceResponse, error)
+}
+
+type readinessAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReadinessAdminClient(cc grpc.ClientConnInterface) ReadinessAdminClient {
+	return &readinessAdminClient{cc}
+}
+
+func (c *readinessAdminClient) AddResource(ctx context.Context, in *AddResourceRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, ReadinessAdmin_AddResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readinessAdminClient) RemoveResource(ctx context.Context, in *RemoveResourceRequest, opts ...grpc.CallOption) (*RemoveResourceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveResourceResponse)
+	err := c.cc.Invoke(ctx, ReadinessAdmin_RemoveResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReadinessAdminServer is the server API for ReadinessAdmin service.
+// All implementations must embed UnimplementedReadinessAdminServer
+// for forward compatibility.
+type ReadinessAdminServer interface {
+	AddResource(context.Context, *AddResourceRequest) (*Status, error)
+	RemoveResource(context.Context, *RemoveResourceRequest) (*RemoveResourceResponse, error)
+	mustEmbedUnimplementedReadinessAdminServer()
+}
+
+// UnimplementedReadinessAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReadinessAdminServer struct{}
+
+func (UnimplementedReadinessAdminServer) AddResource(context.Context, *AddResourceRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddResource not implemented")
+}
+func (UnimplementedReadinessAdminServer) RemoveResource(context.Context, *RemoveResourceRequest) (*RemoveResourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveResource not implemented")
+}
+func (UnimplementedReadinessAdminServer) mustEmbedUnimplementedReadinessAdminServer() {}
+func (UnimplementedReadinessAdminServer) testEmbeddedByValue()                        {}
+
+// UnsafeReadinessAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReadinessAdminServer will
+// result in compilation errors.
+type UnsafeReadinessAdminServer interface {
+	mustEmbedUnimplementedReadinessAdminServer()
+}
+
+func RegisterReadinessAdminServer(s grpc.ServiceRegistrar, srv ReadinessAdminServer) {
+	// If the following call pancis, it indicates UnimplementedReadinessAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReadinessAdmin_ServiceDesc, srv)
+}
+
+func _ReadinessAdmin_AddResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReadinessAdminServer).AddResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReadinessAdmin_AddResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReadinessAdminServer).AddResource(ctx, req.(*AddResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReadinessAdmin_RemoveResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReadinessAdminServer).RemoveResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReadinessAdmin_RemoveResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReadinessAdminServer).RemoveResource(ctx, req.(*RemoveResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReadinessAdmin_ServiceDesc is the grpc.ServiceDesc for ReadinessAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReadinessAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "waitfor.server.v1.ReadinessAdmin",
+	HandlerType: (*ReadinessAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddResource",
+			Handler:    _ReadinessAdmin_AddResource_Handler,
+		},
+		{
+			MethodName: "RemoveResource",
+			Handler:    _ReadinessAdmin_RemoveResource_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "server.proto",
+}
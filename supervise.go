@@ -0,0 +1,88 @@
+package waitfor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff"
+)
+
+const (
+	// RestartAlways restarts the program regardless of its exit status
+	RestartAlways RestartPolicy = iota
+	// RestartOnFailure restarts the program only when it fails
+	RestartOnFailure
+)
+
+type RestartPolicy int
+
+// Supervise runs resource availability tests, executes a given command and restarts it when it exits
+// according to the restart policy. It returns when the policy does not allow another restart
+// or the context is cancelled. Program output is discarded unless Stdout or Stderr are set.
+// Failures other than an exit of the program, e.g. a missing executable, are returned without a restart.
+// The restart interval starts over after a run which lasted at least the maximum restart interval.
+func (r *Runner) Supervise(ctx context.Context, program Program, setters ...Option) error {
+	opts := newOptions(setters)
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = opts.restartInterval
+	b.MaxInterval = opts.restartMaxInterval
+	b.MaxElapsedTime = 0
+	b.Reset()
+
+	var restarts uint64
+	var results []ResourceResult
+
+	for {
+		if restarts == 0 || opts.recheckOnRestart {
+			var err error
+
+			if results, err = r.testProgram(ctx, program, setters); err != nil {
+				return err
+			}
+		} else if err := r.beforeProgram(ctx, program, results, opts); err != nil {
+			return err
+		}
+
+		start := time.Now()
+		_, err := r.runProgram(ctx, program, setters)
+		r.afterProgram(ctx, program, results, err, opts)
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var exitErr *ExitError
+
+		if err != nil && !errors.As(err, &exitErr) {
+			return err
+		}
+
+		// a program which stayed up is restarted quickly again
+		if time.Since(start) >= opts.restartMaxInterval {
+			b.Reset()
+		}
+
+		if err == nil && opts.restartPolicy == RestartOnFailure {
+			return nil
+		}
+
+		if opts.maxRestarts > 0 && restarts >= opts.maxRestarts {
+			if err != nil {
+				return fmt.Errorf("restart limit of %d is reached: %w", opts.maxRestarts, err)
+			}
+
+			return nil
+		}
+
+		restarts++
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(b.NextBackOff()):
+		}
+	}
+}
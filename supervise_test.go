@@ -0,0 +1,60 @@
+package waitfor
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunner_Supervise(t *testing.T) {
+	counter := filepath.Join(t.TempDir(), "counter")
+	r := New()
+
+	err := r.Supervise(
+		context.Background(),
+		Program{
+			Executable: "sh",
+			Args:       []string{"-c", "echo run >> " + counter + "; exit 1"},
+		},
+		WithRestartInterval(0),
+		WithMaxRestarts(2),
+	)
+
+	var exitErr *ExitError
+
+	assert.ErrorAs(t, err, &exitErr)
+
+	data, err := os.ReadFile(counter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(data), "run"))
+}
+
+func TestRunner_Supervise_OnFailure(t *testing.T) {
+	r := New()
+
+	err := r.Supervise(
+		context.Background(),
+		Program{Executable: "true"},
+		WithRestartPolicy(RestartOnFailure),
+	)
+
+	assert.NoError(t, err)
+}
+
+func TestRunner_Supervise_NotFound(t *testing.T) {
+	r := New()
+
+	err := r.Supervise(
+		context.Background(),
+		Program{Executable: "waitfor-missing-executable"},
+		WithRestartInterval(0),
+	)
+
+	assert.ErrorIs(t, err, exec.ErrNotFound)
+}
@@ -0,0 +1,101 @@
+package waitfor
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"text/template"
+)
+
+// templateData is available in program templates
+type templateData struct {
+	Resources []*url.URL
+}
+
+var templateFuncs = template.FuncMap{
+	"scheme": func(u *url.URL) string {
+		return u.Scheme
+	},
+	"host": func(u *url.URL) string {
+		return u.Hostname()
+	},
+	"port": func(u *url.URL) string {
+		return u.Port()
+	},
+	"hostport": func(u *url.URL) string {
+		return u.Host
+	},
+	"path": func(u *url.URL) string {
+		return u.Path
+	},
+	"user": func(u *url.URL) string {
+		return u.User.Username()
+	},
+	"password": func(u *url.URL) string {
+		password, _ := u.User.Password()
+		return password
+	},
+	"query": func(key string, u *url.URL) string {
+		return u.Query().Get(key)
+	},
+}
+
+// expandProgram expands templates like {{ index .Resources 0 | host }} in program arguments and environment variables
+func expandProgram(program Program) (Program, error) {
+	data := templateData{
+		Resources: make([]*url.URL, 0, len(program.Resources)),
+	}
+
+	for _, resource := range program.Resources {
+		u, err := url.Parse(resource)
+
+		if err != nil {
+			return program, err
+		}
+
+		data.Resources = append(data.Resources, u)
+	}
+
+	args, err := expandAll(program.Args, data)
+
+	if err != nil {
+		return program, err
+	}
+
+	env, err := expandAll(program.Env, data)
+
+	if err != nil {
+		return program, err
+	}
+
+	program.Args = args
+	program.Env = env
+
+	return program, nil
+}
+
+func expandAll(values []string, data templateData) ([]string, error) {
+	if values == nil {
+		return nil, nil
+	}
+
+	out := make([]string, 0, len(values))
+
+	for _, value := range values {
+		tmpl, err := template.New("").Funcs(templateFuncs).Option("missingkey=error").Parse(value)
+
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", value, err)
+		}
+
+		var buf bytes.Buffer
+
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("%q: %w", value, err)
+		}
+
+		out = append(out, buf.String())
+	}
+
+	return out, nil
+}
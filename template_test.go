@@ -0,0 +1,33 @@
+package waitfor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandProgram(t *testing.T) {
+	program, err := expandProgram(Program{
+		Executable: "myapp",
+		Args: []string{
+			"--host", "{{ index .Resources 0 | host }}",
+			"--port={{ index .Resources 0 | port }}",
+			"{{ index .Resources 1 }}",
+		},
+		Env:       []string{"DB_NAME={{ index .Resources 0 | path }}", "SSL={{ index .Resources 0 | query \"sslmode\" }}"},
+		Resources: []string{"postgres://db:5432/mydb?sslmode=disable", "http://localhost:8080/health"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"--host", "db", "--port=5432", "http://localhost:8080/health"}, program.Args)
+	assert.Equal(t, []string{"DB_NAME=/mydb", "SSL=disable"}, program.Env)
+}
+
+func TestExpandProgram_Error(t *testing.T) {
+	_, err := expandProgram(Program{
+		Args:      []string{"{{ index .Resources 1 | host }}"},
+		Resources: []string{"postgres://db:5432/mydb"},
+	})
+
+	assert.Error(t, err)
+}
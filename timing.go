@@ -0,0 +1,37 @@
+package waitfor
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// Timing holds latencies of resource test attempts, the total time to ready is ResourceResult.Duration
+type Timing struct {
+	// Latencies of every attempt in the order of execution
+	Latencies []time.Duration
+}
+
+// First returns the latency of the first attempt or zero if there were no attempts
+func (t Timing) First() time.Duration {
+	if len(t.Latencies) == 0 {
+		return 0
+	}
+
+	return t.Latencies[0]
+}
+
+// Percentile returns the nearest-rank percentile of attempt latencies, e.g. 50 for the median,
+// or zero if there were no attempts
+func (t Timing) Percentile(p float64) time.Duration {
+	if len(t.Latencies) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(t.Latencies)
+	slices.Sort(sorted)
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
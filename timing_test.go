@@ -0,0 +1,39 @@
+package waitfor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTiming(t *testing.T) {
+	timing := Timing{Latencies: []time.Duration{40, 10, 30, 20, 50}}
+
+	assert.Equal(t, time.Duration(40), timing.First())
+	assert.Equal(t, time.Duration(10), timing.Percentile(0))
+	assert.Equal(t, time.Duration(10), timing.Percentile(20))
+	assert.Equal(t, time.Duration(30), timing.Percentile(50))
+	assert.Equal(t, time.Duration(50), timing.Percentile(95))
+	assert.Equal(t, time.Duration(50), timing.Percentile(100))
+	assert.Equal(t, []time.Duration{40, 10, 30, 20, 50}, timing.Latencies)
+
+	assert.Zero(t, Timing{}.First())
+	assert.Zero(t, Timing{}.Percentile(50))
+}
+
+func TestRunner_Test_Timing(t *testing.T) {
+	var results []ResourceResult
+
+	r := New(useFlakyResource(&flakyResource{failures: 2}))
+	err := r.Test(context.Background(), []string{"flaky://localhost"}, WithInterval(0), WithCompletionHook(func(_ context.Context, res []ResourceResult, _ error) {
+		results = res
+	}))
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, 3, results[0].Attempts)
+	assert.Len(t, results[0].Timing.Latencies, 3)
+	assert.LessOrEqual(t, results[0].Timing.Percentile(100), results[0].Duration)
+}
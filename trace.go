@@ -0,0 +1,40 @@
+package waitfor
+
+import (
+	"net/url"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation scope of emitted spans
+const tracerName = "github.com/go-waitfor/waitfor"
+
+// noopTracer is used when no tracer provider is configured
+var noopTracer = noop.NewTracerProvider().Tracer(tracerName)
+
+// resourceAttributes describes a resource location without its credentials and options
+func resourceAttributes(resource string) []attribute.KeyValue {
+	u, err := url.Parse(resource)
+
+	if err != nil {
+		return nil
+	}
+
+	return []attribute.KeyValue{
+		attribute.String("waitfor.resource.scheme", u.Scheme),
+		attribute.String("waitfor.resource.host", u.Host),
+	}
+}
+
+// endSpan records an error, if any, and ends a span
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
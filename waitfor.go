@@ -1,25 +1,31 @@
 package waitfor
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"os/exec"
+	"errors"
+	"io"
 	"sync"
+	"time"
 
 	"github.com/cenkalti/backoff"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type (
-	Program struct {
-		Executable string
-		Args       []string
-		Resources  []string
-	}
-
 	Runner struct {
 		registry *Registry
 	}
+
+	// ResourceResult is an outcome of a resource availability test
+	ResourceResult struct {
+		Resource string
+		Duration time.Duration
+		// Attempts is the number of tests made, it is zero if the resource cannot be resolved
+		Attempts int
+		Timing   Timing
+		Err      error
+	}
 )
 
 func New(configurators ...ResourceConfig) *Runner {
@@ -35,43 +41,125 @@ func (r *Runner) Resources() *Registry {
 }
 
 // Run runs resource availability tests and execute a given command
-func (r *Runner) Run(ctx context.Context, program Program, setters ...Option) ([]byte, error) {
-	err := r.Test(ctx, program.Resources, setters...)
+func (r *Runner) Run(ctx context.Context, program Program, setters ...Option) (out []byte, err error) {
+	ctx, span := newOptions(setters).tracer.Start(ctx, "waitfor.Run", trace.WithAttributes(
+		attribute.String("waitfor.program", program.Executable),
+	))
+	defer func() { endSpan(span, err) }()
+
+	results, err := r.testProgram(ctx, program, setters)
 
 	if err != nil {
 		return nil, err
 	}
 
-	cmd := exec.Command(program.Executable, program.Args...)
+	out, err = r.retryProgram(ctx, program, setters)
+	r.afterProgram(ctx, program, results, err, newOptions(setters))
 
-	return cmd.CombinedOutput()
+	return out, err
+}
+
+// Start runs resource availability tests and starts a given command without waiting for its completion
+func (r *Runner) Start(ctx context.Context, program Program, setters ...Option) (*Process, error) {
+	results, err := r.testProgram(ctx, program, setters)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := r.startProgram(ctx, program, setters)
+
+	if err != nil {
+		r.afterProgram(ctx, program, results, err, newOptions(setters))
+		return nil, err
+	}
+
+	p.after = func(err error) {
+		r.afterProgram(ctx, program, results, err, newOptions(setters))
+	}
+
+	return p, nil
+}
+
+// Exec runs resource availability tests and replaces the current process with a given command.
+// On success it never returns. Program I/O settings are ignored, the new process inherits the current ones.
+func (r *Runner) Exec(ctx context.Context, program Program, setters ...Option) error {
+	_, err := r.testProgram(ctx, program, setters)
+
+	if err != nil {
+		return err
+	}
+
+	program, err = prepareProgram(program, newOptions(setters))
+
+	if err != nil {
+		return err
+	}
+
+	return execProgram(program)
 }
 
 // Test tests resource availability
-func (r *Runner) Test(ctx context.Context, resources []string, setters ...Option) error {
+func (r *Runner) Test(ctx context.Context, resources []string, setters ...Option) (err error) {
 	opts := newOptions(setters)
 
-	var buff bytes.Buffer
+	ctx, span := opts.tracer.Start(ctx, "waitfor.Test")
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.testAll(ctx, resources, opts)
+
+	return err
+}
+
+// TestFrom tests availability of resources read from a given reader, see ReadResources
+func (r *Runner) TestFrom(ctx context.Context, rd io.Reader, setters ...Option) error {
+	resources, err := ReadResources(rd)
+
+	if err != nil {
+		return err
+	}
+
+	return r.Test(ctx, resources, setters...)
+}
+
+// testAll tests resource availability and returns results in the order of completion
+func (r *Runner) testAll(ctx context.Context, resources []string, opts *Options) ([]ResourceResult, error) {
+	var failures []ResourceFailure
+
+	r.beforeTest(ctx, resources, opts)
+
+	results := make([]ResourceResult, 0, len(resources))
 	output := r.testAllInternal(ctx, resources, *opts)
 
-	for err := range output {
-		if err != nil {
-			buff.WriteString(err.Error() + ";")
+	for res := range output {
+		results = append(results, res)
+
+		if res.Err != nil {
+			failures = append(failures, ResourceFailure{
+				Resource: res.Resource,
+				Attempts: res.Attempts,
+				Duration: res.Duration,
+				Err:      res.Err,
+			})
 		}
 	}
 
-	if buff.Len() != 0 {
-		return fmt.Errorf("%s: %s", ErrWait, buff.String())
+	var err error
+
+	if len(failures) != 0 {
+		err = newWaitError(failures)
 	}
 
-	return nil
+	r.afterTest(ctx, results, err, opts)
+
+	return results, err
 }
 
-func (r *Runner) testAllInternal(ctx context.Context, resources []string, opts Options) <-chan error {
+func (r *Runner) testAllInternal(ctx context.Context, resources []string, opts Options) <-chan ResourceResult {
 	var wg sync.WaitGroup
 	wg.Add(len(resources))
 
-	output := make(chan error, len(resources))
+	output := make(chan ResourceResult, len(resources))
 
 	for _, resource := range resources {
 		resource := resource
@@ -79,7 +167,18 @@ func (r *Runner) testAllInternal(ctx context.Context, resources []string, opts O
 		go func() {
 			defer wg.Done()
 
-			output <- r.testInternal(ctx, resource, opts)
+			start := time.Now()
+			timing, err := r.testInternal(ctx, resource, opts.forResource(resource))
+			res := ResourceResult{
+				Resource: resource,
+				Duration: time.Since(start),
+				Attempts: len(timing.Latencies),
+				Timing:   timing,
+				Err:      err,
+			}
+
+			r.afterResource(ctx, res, &opts)
+			output <- res
 		}()
 	}
 
@@ -91,18 +190,102 @@ func (r *Runner) testAllInternal(ctx context.Context, resources []string, opts O
 	return output
 }
 
-func (r *Runner) testInternal(ctx context.Context, resource string, opts Options) error {
+// testInternal tests a resource until it is available or the attempts are exhausted and returns latencies of the attempts
+func (r *Runner) testInternal(ctx context.Context, resource string, opts Options) (timing Timing, err error) {
+	ctx, span := opts.tracer.Start(ctx, "waitfor.resource", trace.WithAttributes(resourceAttributes(resource)...))
+	defer func() { endSpan(span, err) }()
+
+	logger := opts.logger.With("resource", Redact(resource))
 	rsc, err := r.registry.Resolve(resource)
 
 	if err != nil {
-		return err
+		logger.ErrorContext(ctx, "resource cannot be resolved", "error", err)
+		return timing, err
+	}
+
+	logger.DebugContext(ctx, "resource resolved")
+
+	b := backoff.WithContext(backoff.WithMaxRetries(newBackOff(opts), opts.attempts), ctx)
+	b.Reset()
+
+	start := time.Now()
+	attempt := Attempt{Resource: resource}
+
+	for {
+		attempt.Number++
+		logger.DebugContext(ctx, "testing resource", "attempt", attempt.Number)
+
+		attempt.Duration, attempt.Err = r.testAttempt(ctx, rsc, attempt.Number, opts)
+		timing.Latencies = append(timing.Latencies, attempt.Duration)
+		attempt.Delay = 0
+
+		retry := false
+
+		if attempt.Err != nil && !errors.Is(attempt.Err, ErrPermanent) {
+			if delay := b.NextBackOff(); delay != backoff.Stop {
+				attempt.Delay, retry = delay, true
+			}
+		}
+
+		r.afterAttempt(ctx, attempt, &opts)
+
+		if !retry {
+			break
+		}
+
+		logger.InfoContext(ctx, "resource is not available, retrying", "attempt", attempt.Number, "delay", attempt.Delay, "error", attempt.Err)
+
+		if !sleep(ctx, attempt.Delay) {
+			break
+		}
+	}
+
+	span.SetAttributes(attribute.Int("waitfor.attempts", attempt.Number))
+
+	if attempt.Err != nil {
+		logger.ErrorContext(ctx, "resource is not available", "attempts", attempt.Number, "duration", time.Since(start), "error", attempt.Err)
+		return timing, attempt.Err
+	}
+
+	logger.InfoContext(ctx, "resource is available", "attempts", attempt.Number, "duration", time.Since(start))
+
+	return timing, nil
+}
+
+// testAttempt tests a resource once
+func (r *Runner) testAttempt(ctx context.Context, rsc Resource, number int, opts Options) (time.Duration, error) {
+	ctx, span := opts.tracer.Start(ctx, "waitfor.attempt", trace.WithAttributes(attribute.Int("waitfor.attempt", number)))
+
+	start := time.Now()
+	err := rsc.Test(ctx)
+	endSpan(span, err)
+
+	return time.Since(start), err
+}
+
+// sleep waits for a given delay and reports false if the context is done earlier
+func sleep(ctx context.Context, delay time.Duration) bool {
+	t := time.NewTimer(delay)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
 	}
+}
 
+// newBackOff creates an exponential backoff with configured intervals,
+// unlimited attempts are not cut off by the default maximum elapsed time of the backoff
+func newBackOff(opts Options) backoff.BackOff {
 	b := backoff.NewExponentialBackOff()
 	b.InitialInterval = opts.interval
 	b.MaxInterval = opts.maxInterval
 
-	return backoff.Retry(func() error {
-		return rsc.Test(ctx)
-	}, backoff.WithContext(backoff.WithMaxRetries(b, opts.attempts), ctx))
+	if opts.attempts == 0 {
+		b.MaxElapsedTime = 0
+	}
+
+	return b
 }
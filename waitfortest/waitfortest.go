@@ -0,0 +1,77 @@
+// Package waitfortest provides helpers for tests which depend on external resources, e.g.
+//
+//	func TestRepository(t *testing.T) {
+//		waitfortest.Require(t, waitfor.New(postgres.Use()), []string{"postgres://localhost:5432/test"})
+//		...
+//	}
+package waitfortest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-waitfor/waitfor"
+	"github.com/go-waitfor/waitfor/report"
+)
+
+// Fast retry settings of test environments, they give resources about half a minute
+const (
+	DefaultInterval    = 100 * time.Millisecond
+	DefaultMaxInterval = time.Second
+	DefaultAttempts    = 30
+)
+
+// Defaults returns runner options with fast retry settings of test environments
+func Defaults() []waitfor.Option {
+	return []waitfor.Option{
+		waitfor.WithIntervalDurations(DefaultInterval, DefaultMaxInterval),
+		waitfor.WithAttempts(DefaultAttempts),
+	}
+}
+
+// Require waits for resources and stops the test if some of them never become ready.
+// Options follow the defaults and override them.
+func Require(t testing.TB, runner *waitfor.Runner, resources []string, setters ...waitfor.Option) {
+	t.Helper()
+
+	if message, ok := wait(t.Context(), runner, resources, setters); !ok {
+		t.Fatal(message)
+	}
+}
+
+// Assert waits for resources and marks the test as failed if some of them never become ready,
+// it reports whether all resources are ready
+func Assert(t testing.TB, runner *waitfor.Runner, resources []string, setters ...waitfor.Option) bool {
+	t.Helper()
+
+	message, ok := wait(t.Context(), runner, resources, setters)
+
+	if !ok {
+		t.Error(message)
+	}
+
+	return ok
+}
+
+// wait tests resources and describes every resource if some of them are not ready
+func wait(ctx context.Context, runner *waitfor.Runner, resources []string, setters []waitfor.Option) (string, bool) {
+	var results []waitfor.ResourceResult
+
+	setters = append(Defaults(), setters...)
+	setters = append(setters, waitfor.WithCompletionHook(func(_ context.Context, res []waitfor.ResourceResult, _ error) {
+		results = res
+	}))
+
+	if err := runner.Test(ctx, resources, setters...); err == nil {
+		return "", true
+	}
+
+	var b strings.Builder
+
+	b.WriteString("resources never became ready:\n\n")
+	_ = report.WriteTable(&b, results, false)
+
+	return b.String(), false
+}